@@ -78,7 +78,7 @@ func (r *AccountService) List(ctx context.Context, query AccountListParams, opts
 
 // List Accounts
 func (r *AccountService) ListAutoPaging(ctx context.Context, query AccountListParams, opts ...option.RequestOption) *shared.PageAutoPager[Account] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Retrieve an Account Balance
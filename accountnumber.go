@@ -79,7 +79,7 @@ func (r *AccountNumberService) List(ctx context.Context, query AccountNumberList
 
 // List Account Numbers
 func (r *AccountNumberService) ListAutoPaging(ctx context.Context, query AccountNumberListParams, opts ...option.RequestOption) *shared.PageAutoPager[AccountNumber] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Each account can have multiple account and routing numbers. We recommend that
@@ -63,7 +63,7 @@ func (r *AccountStatementService) List(ctx context.Context, query AccountStateme
 
 // List Account Statements
 func (r *AccountStatementService) ListAutoPaging(ctx context.Context, query AccountStatementListParams, opts ...option.RequestOption) *shared.PageAutoPager[AccountStatement] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Account Statements are generated monthly for every active Account. You can
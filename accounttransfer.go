@@ -71,7 +71,7 @@ func (r *AccountTransferService) List(ctx context.Context, query AccountTransfer
 
 // List Account Transfers
 func (r *AccountTransferService) ListAutoPaging(ctx context.Context, query AccountTransferListParams, opts ...option.RequestOption) *shared.PageAutoPager[AccountTransfer] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Approve an Account Transfer
@@ -71,7 +71,7 @@ func (r *ACHPrenotificationService) List(ctx context.Context, query ACHPrenotifi
 
 // List ACH Prenotifications
 func (r *ACHPrenotificationService) ListAutoPaging(ctx context.Context, query ACHPrenotificationListParams, opts ...option.RequestOption) *shared.PageAutoPager[ACHPrenotification] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // ACH Prenotifications are one way you can verify account and routing numbers by
@@ -71,7 +71,7 @@ func (r *ACHTransferService) List(ctx context.Context, query ACHTransferListPara
 
 // List ACH Transfers
 func (r *ACHTransferService) ListAutoPaging(ctx context.Context, query ACHTransferListParams, opts ...option.RequestOption) *shared.PageAutoPager[ACHTransfer] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Approves an ACH Transfer in a pending_approval state.
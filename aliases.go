@@ -4,6 +4,95 @@ package increase
 
 import (
 	"github.com/increase/increase-go/internal/apierror"
+	"github.com/increase/increase-go/internal/circuitbreaker"
 )
 
 type Error = apierror.Error
+
+// CircuitOpenError is returned, without sending the request, while the circuit
+// of an [option.CircuitBreaker] is open.
+type CircuitOpenError = circuitbreaker.OpenError
+
+// ErrCircuitOpen matches every [*CircuitOpenError] with errors.Is.
+var ErrCircuitOpen = circuitbreaker.ErrOpen
+
+// ErrTimeout matches, with errors.Is, every error caused by a request running
+// out of time.
+var ErrTimeout = apierror.ErrTimeout
+
+// ErrConnection matches every [*ConnectionError] with errors.Is.
+var ErrConnection = apierror.ErrConnection
+
+// ConnectionError is returned when no response was received for a request.
+type ConnectionError = apierror.ConnectionError
+
+// DecodeError is returned when a response body could not be decoded. It carries
+// the raw body and the type it was decoded into.
+type DecodeError = apierror.DecodeError
+
+// EncodeError is returned, without sending the request, when the request params
+// could not be encoded.
+type EncodeError = apierror.EncodeError
+
+// IsRetryable reports whether the request that failed with err may succeed when
+// sent again.
+func IsRetryable(err error) bool {
+	return apierror.IsRetryable(err)
+}
+
+// The type of an [*Error], such as "invalid_parameters_error".
+type ErrorType = apierror.ErrorType
+
+const (
+	ErrorTypeAPIMethodNotFoundError             = apierror.ErrorTypeAPIMethodNotFoundError
+	ErrorTypeEnvironmentMismatchError           = apierror.ErrorTypeEnvironmentMismatchError
+	ErrorTypeIdempotencyKeyAlreadyUsedError     = apierror.ErrorTypeIdempotencyKeyAlreadyUsedError
+	ErrorTypeInsufficientPermissionsError       = apierror.ErrorTypeInsufficientPermissionsError
+	ErrorTypeInternalServerError                = apierror.ErrorTypeInternalServerError
+	ErrorTypeInvalidAPIKeyError                 = apierror.ErrorTypeInvalidAPIKeyError
+	ErrorTypeInvalidOperationError              = apierror.ErrorTypeInvalidOperationError
+	ErrorTypeInvalidParametersError             = apierror.ErrorTypeInvalidParametersError
+	ErrorTypeMalformedRequestError              = apierror.ErrorTypeMalformedRequestError
+	ErrorTypeObjectNotFoundError                = apierror.ErrorTypeObjectNotFoundError
+	ErrorTypePrivateFeatureError                = apierror.ErrorTypePrivateFeatureError
+	ErrorTypeRateLimitedError                   = apierror.ErrorTypeRateLimitedError
+	ErrorTypeUniqueIdentifierAlreadyExistsError = apierror.ErrorTypeUniqueIdentifierAlreadyExistsError
+)
+
+// The HTTP status of an [*Error].
+type ErrorStatus = apierror.ErrorStatus
+
+const (
+	ErrorStatus400 = apierror.ErrorStatus400
+	ErrorStatus401 = apierror.ErrorStatus401
+	ErrorStatus403 = apierror.ErrorStatus403
+	ErrorStatus404 = apierror.ErrorStatus404
+	ErrorStatus409 = apierror.ErrorStatus409
+	ErrorStatus429 = apierror.ErrorStatus429
+	ErrorStatus500 = apierror.ErrorStatus500
+)
+
+// The typed errors below are reachable from an [*Error] of the matching
+// [ErrorType] with errors.As, for example:
+//
+//	var notFound *increase.ObjectNotFoundError
+//	if errors.As(err, &notFound) { ... }
+type (
+	APIMethodNotFoundError             = apierror.APIMethodNotFoundError
+	EnvironmentMismatchError           = apierror.EnvironmentMismatchError
+	IdempotencyKeyAlreadyUsedError     = apierror.IdempotencyKeyAlreadyUsedError
+	InsufficientPermissionsError       = apierror.InsufficientPermissionsError
+	InternalServerError                = apierror.InternalServerError
+	InvalidAPIKeyError                 = apierror.InvalidAPIKeyError
+	InvalidOperationError              = apierror.InvalidOperationError
+	InvalidParametersError             = apierror.InvalidParametersError
+	MalformedRequestError              = apierror.MalformedRequestError
+	ObjectNotFoundError                = apierror.ObjectNotFoundError
+	PrivateFeatureError                = apierror.PrivateFeatureError
+	RateLimitedError                   = apierror.RateLimitedError
+	UniqueIdentifierAlreadyExistsError = apierror.UniqueIdentifierAlreadyExistsError
+)
+
+// ParameterError describes a single parameter of an [*InvalidParametersError],
+// with a JSON pointer to it within the request body.
+type ParameterError = apierror.ParameterError
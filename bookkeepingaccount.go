@@ -71,7 +71,7 @@ func (r *BookkeepingAccountService) List(ctx context.Context, query BookkeepingA
 
 // List Bookkeeping Accounts
 func (r *BookkeepingAccountService) ListAutoPaging(ctx context.Context, query BookkeepingAccountListParams, opts ...option.RequestOption) *shared.PageAutoPager[BookkeepingAccount] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Retrieve a Bookkeeping Account Balance
@@ -63,7 +63,7 @@ func (r *BookkeepingEntryService) List(ctx context.Context, query BookkeepingEnt
 
 // List Bookkeeping Entries
 func (r *BookkeepingEntryService) ListAutoPaging(ctx context.Context, query BookkeepingEntryListParams, opts ...option.RequestOption) *shared.PageAutoPager[BookkeepingEntry] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Entries are T-account entries recording debits and credits. Your compliance
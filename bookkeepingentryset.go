@@ -71,7 +71,7 @@ func (r *BookkeepingEntrySetService) List(ctx context.Context, query Bookkeeping
 
 // List Bookkeeping Entry Sets
 func (r *BookkeepingEntrySetService) ListAutoPaging(ctx context.Context, query BookkeepingEntrySetListParams, opts ...option.RequestOption) *shared.PageAutoPager[BookkeepingEntrySet] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Entry Sets are accounting entries that are transactionally applied. Your
@@ -78,7 +78,7 @@ func (r *CardService) List(ctx context.Context, query CardListParams, opts ...op
 
 // List Cards
 func (r *CardService) ListAutoPaging(ctx context.Context, query CardListParams, opts ...option.RequestOption) *shared.PageAutoPager[Card] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Retrieve sensitive details for a Card
@@ -71,7 +71,7 @@ func (r *CardDisputeService) List(ctx context.Context, query CardDisputeListPara
 
 // List Card Disputes
 func (r *CardDisputeService) ListAutoPaging(ctx context.Context, query CardDisputeListParams, opts ...option.RequestOption) *shared.PageAutoPager[CardDispute] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // If unauthorized activity occurs on a card, you can create a Card Dispute and
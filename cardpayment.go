@@ -63,7 +63,7 @@ func (r *CardPaymentService) List(ctx context.Context, query CardPaymentListPara
 
 // List Card Payments
 func (r *CardPaymentService) ListAutoPaging(ctx context.Context, query CardPaymentListParams, opts ...option.RequestOption) *shared.PageAutoPager[CardPayment] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Card Payments group together interactions related to a single card payment, such
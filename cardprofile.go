@@ -71,7 +71,7 @@ func (r *CardProfileService) List(ctx context.Context, query CardProfileListPara
 
 // List Card Profiles
 func (r *CardProfileService) ListAutoPaging(ctx context.Context, query CardProfileListParams, opts ...option.RequestOption) *shared.PageAutoPager[CardProfile] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Archive an Card Profile
@@ -63,7 +63,7 @@ func (r *CardPurchaseSupplementService) List(ctx context.Context, query CardPurc
 
 // List Card Purchase Supplements
 func (r *CardPurchaseSupplementService) ListAutoPaging(ctx context.Context, query CardPurchaseSupplementListParams, opts ...option.RequestOption) *shared.PageAutoPager[CardPurchaseSupplement] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Additional information about a card purchase (e.g., settlement or refund), such
@@ -71,7 +71,7 @@ func (r *CheckDepositService) List(ctx context.Context, query CheckDepositListPa
 
 // List Check Deposits
 func (r *CheckDepositService) ListAutoPaging(ctx context.Context, query CheckDepositListParams, opts ...option.RequestOption) *shared.PageAutoPager[CheckDeposit] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Check Deposits allow you to deposit images of paper checks into your account.
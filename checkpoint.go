@@ -0,0 +1,287 @@
+package increase
+
+import (
+	"context"
+
+	"github.com/increase/increase-go/internal/shared"
+	"github.com/increase/increase-go/option"
+)
+
+// Checkpoint is the position of an auto-pager in its list, returned by its
+// Checkpoint method. It can be marshaled to JSON and stored, to resume the
+// iteration later with the ResumeAutoPager method of the service.
+type Checkpoint = shared.Checkpoint
+
+// ResumeAutoPager continues a [ACHPrenotificationService.ListAutoPaging]
+// iteration from the checkpoint, returning the items after the one it was taken
+// at.
+func (r *ACHPrenotificationService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[ACHPrenotification] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[ACHPrenotification](ctx, "ach_prenotifications", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [ACHTransferService.ListAutoPaging] iteration
+// from the checkpoint, returning the items after the one it was taken at.
+func (r *ACHTransferService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[ACHTransfer] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[ACHTransfer](ctx, "ach_transfers", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [AccountNumberService.ListAutoPaging] iteration
+// from the checkpoint, returning the items after the one it was taken at.
+func (r *AccountNumberService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[AccountNumber] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[AccountNumber](ctx, "account_numbers", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [AccountService.ListAutoPaging] iteration from
+// the checkpoint, returning the items after the one it was taken at.
+func (r *AccountService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[Account] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[Account](ctx, "accounts", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [AccountStatementService.ListAutoPaging]
+// iteration from the checkpoint, returning the items after the one it was taken
+// at.
+func (r *AccountStatementService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[AccountStatement] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[AccountStatement](ctx, "account_statements", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [AccountTransferService.ListAutoPaging] iteration
+// from the checkpoint, returning the items after the one it was taken at.
+func (r *AccountTransferService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[AccountTransfer] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[AccountTransfer](ctx, "account_transfers", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [BookkeepingAccountService.ListAutoPaging]
+// iteration from the checkpoint, returning the items after the one it was taken
+// at.
+func (r *BookkeepingAccountService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[BookkeepingAccount] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[BookkeepingAccount](ctx, "bookkeeping_accounts", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [BookkeepingEntryService.ListAutoPaging]
+// iteration from the checkpoint, returning the items after the one it was taken
+// at.
+func (r *BookkeepingEntryService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[BookkeepingEntry] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[BookkeepingEntry](ctx, "bookkeeping_entries", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [BookkeepingEntrySetService.ListAutoPaging]
+// iteration from the checkpoint, returning the items after the one it was taken
+// at.
+func (r *BookkeepingEntrySetService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[BookkeepingEntrySet] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[BookkeepingEntrySet](ctx, "bookkeeping_entry_sets", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [CardDisputeService.ListAutoPaging] iteration
+// from the checkpoint, returning the items after the one it was taken at.
+func (r *CardDisputeService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[CardDispute] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[CardDispute](ctx, "card_disputes", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [CardPaymentService.ListAutoPaging] iteration
+// from the checkpoint, returning the items after the one it was taken at.
+func (r *CardPaymentService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[CardPayment] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[CardPayment](ctx, "card_payments", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [CardProfileService.ListAutoPaging] iteration
+// from the checkpoint, returning the items after the one it was taken at.
+func (r *CardProfileService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[CardProfile] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[CardProfile](ctx, "card_profiles", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [CardPurchaseSupplementService.ListAutoPaging]
+// iteration from the checkpoint, returning the items after the one it was taken
+// at.
+func (r *CardPurchaseSupplementService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[CardPurchaseSupplement] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[CardPurchaseSupplement](ctx, "card_purchase_supplements", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [CardService.ListAutoPaging] iteration from the
+// checkpoint, returning the items after the one it was taken at.
+func (r *CardService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[Card] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[Card](ctx, "cards", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [CheckDepositService.ListAutoPaging] iteration
+// from the checkpoint, returning the items after the one it was taken at.
+func (r *CheckDepositService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[CheckDeposit] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[CheckDeposit](ctx, "check_deposits", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [CheckTransferService.ListAutoPaging] iteration
+// from the checkpoint, returning the items after the one it was taken at.
+func (r *CheckTransferService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[CheckTransfer] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[CheckTransfer](ctx, "check_transfers", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [DeclinedTransactionService.ListAutoPaging]
+// iteration from the checkpoint, returning the items after the one it was taken
+// at.
+func (r *DeclinedTransactionService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[DeclinedTransaction] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[DeclinedTransaction](ctx, "declined_transactions", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [DigitalWalletTokenService.ListAutoPaging]
+// iteration from the checkpoint, returning the items after the one it was taken
+// at.
+func (r *DigitalWalletTokenService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[DigitalWalletToken] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[DigitalWalletToken](ctx, "digital_wallet_tokens", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [DocumentService.ListAutoPaging] iteration from
+// the checkpoint, returning the items after the one it was taken at.
+func (r *DocumentService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[Document] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[Document](ctx, "documents", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [EntityService.ListAutoPaging] iteration from the
+// checkpoint, returning the items after the one it was taken at.
+func (r *EntityService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[Entity] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[Entity](ctx, "entities", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a
+// [EntitySupplementalDocumentService.ListAutoPaging] iteration from the
+// checkpoint, returning the items after the one it was taken at.
+func (r *EntitySupplementalDocumentService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[SupplementalDocument] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[SupplementalDocument](ctx, "entity_supplemental_documents", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [EventService.ListAutoPaging] iteration from the
+// checkpoint, returning the items after the one it was taken at.
+func (r *EventService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[Event] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[Event](ctx, "events", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [EventSubscriptionService.ListAutoPaging]
+// iteration from the checkpoint, returning the items after the one it was taken
+// at.
+func (r *EventSubscriptionService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[EventSubscription] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[EventSubscription](ctx, "event_subscriptions", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [ExportService.ListAutoPaging] iteration from the
+// checkpoint, returning the items after the one it was taken at.
+func (r *ExportService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[Export] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[Export](ctx, "exports", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [ExternalAccountService.ListAutoPaging] iteration
+// from the checkpoint, returning the items after the one it was taken at.
+func (r *ExternalAccountService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[ExternalAccount] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[ExternalAccount](ctx, "external_accounts", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [FileService.ListAutoPaging] iteration from the
+// checkpoint, returning the items after the one it was taken at.
+func (r *FileService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[File] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[File](ctx, "files", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [InboundACHTransferService.ListAutoPaging]
+// iteration from the checkpoint, returning the items after the one it was taken
+// at.
+func (r *InboundACHTransferService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[InboundACHTransfer] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[InboundACHTransfer](ctx, "inbound_ach_transfers", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a
+// [InboundWireDrawdownRequestService.ListAutoPaging] iteration from the
+// checkpoint, returning the items after the one it was taken at.
+func (r *InboundWireDrawdownRequestService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[InboundWireDrawdownRequest] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[InboundWireDrawdownRequest](ctx, "inbound_wire_drawdown_requests", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [OauthConnectionService.ListAutoPaging] iteration
+// from the checkpoint, returning the items after the one it was taken at.
+func (r *OauthConnectionService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[OauthConnection] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[OauthConnection](ctx, "oauth_connections", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [PendingTransactionService.ListAutoPaging]
+// iteration from the checkpoint, returning the items after the one it was taken
+// at.
+func (r *PendingTransactionService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[PendingTransaction] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[PendingTransaction](ctx, "pending_transactions", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [PhysicalCardService.ListAutoPaging] iteration
+// from the checkpoint, returning the items after the one it was taken at.
+func (r *PhysicalCardService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[PhysicalCard] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[PhysicalCard](ctx, "physical_cards", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [ProgramService.ListAutoPaging] iteration from
+// the checkpoint, returning the items after the one it was taken at.
+func (r *ProgramService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[Program] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[Program](ctx, "programs", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [RealTimePaymentsTransferService.ListAutoPaging]
+// iteration from the checkpoint, returning the items after the one it was taken
+// at.
+func (r *RealTimePaymentsTransferService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[RealTimePaymentsTransfer] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[RealTimePaymentsTransfer](ctx, "real_time_payments_transfers", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [RoutingNumberService.ListAutoPaging] iteration
+// from the checkpoint, returning the items after the one it was taken at.
+func (r *RoutingNumberService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[RoutingNumber] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[RoutingNumber](ctx, "routing_numbers", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [TransactionService.ListAutoPaging] iteration
+// from the checkpoint, returning the items after the one it was taken at.
+func (r *TransactionService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[Transaction] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[Transaction](ctx, "transactions", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [WireDrawdownRequestService.ListAutoPaging]
+// iteration from the checkpoint, returning the items after the one it was taken
+// at.
+func (r *WireDrawdownRequestService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[WireDrawdownRequest] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[WireDrawdownRequest](ctx, "wire_drawdown_requests", checkpoint, opts...)
+}
+
+// ResumeAutoPager continues a [WireTransferService.ListAutoPaging] iteration
+// from the checkpoint, returning the items after the one it was taken at.
+func (r *WireTransferService) ResumeAutoPager(ctx context.Context, checkpoint Checkpoint, opts ...option.RequestOption) *shared.PageAutoPager[WireTransfer] {
+	opts = append(r.Options[:], opts...)
+	return shared.ResumePageAutoPager[WireTransfer](ctx, "wire_transfers", checkpoint, opts...)
+}
@@ -0,0 +1,104 @@
+package increase_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/increase/increase-go"
+	"github.com/increase/increase-go/option"
+)
+
+func TestResumeAutoPager(t *testing.T) {
+	client, transport := newPagedClient(250)
+	params := increase.AccountListParams{Limit: increase.F(int64(100))}
+	iter := client.Accounts.ListAutoPaging(context.Background(), params)
+	for i := 0; i < 150; i++ {
+		if !iter.Next() {
+			t.Fatal(iter.Err())
+		}
+	}
+	iter.Close()
+
+	raw, err := json.Marshal(iter.Checkpoint())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var checkpoint increase.Checkpoint
+	if err := json.Unmarshal(raw, &checkpoint); err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint != (increase.Checkpoint{Path: "accounts", Query: "limit=100", Cursor: "100", Index: 50}) {
+		t.Fatalf("unexpected checkpoint %+v", checkpoint)
+	}
+
+	resumed := client.Accounts.ResumeAutoPager(context.Background(), checkpoint)
+	n := 150
+	for resumed.Next() {
+		if resumed.Current().ID != fmt.Sprintf("account_%d", n) {
+			t.Fatalf("expected account_%d, got %s", n, resumed.Current().ID)
+		}
+		n++
+	}
+	if err := resumed.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 250 || transport.queries[len(transport.queries)-2] != "cursor=100&limit=100" {
+		t.Fatalf("expected to resume from the second page, got %d accounts after %v", n, transport.queries)
+	}
+	if !resumed.Checkpoint().Done {
+		t.Fatalf("expected a finished iteration to give a done checkpoint, got %+v", resumed.Checkpoint())
+	}
+}
+
+func TestResumeAutoPagerRejectsOtherList(t *testing.T) {
+	client, transport := newPagedClient(10)
+	iter := client.Transactions.ResumeAutoPager(context.Background(), increase.Checkpoint{Path: "accounts"})
+	if iter.Next() || iter.Err() == nil || transport.requests() != 0 {
+		t.Fatalf("expected a checkpoint of another list to fail without a request, got %v", iter.Err())
+	}
+}
+
+func TestCheckpointAfterFirstPageFails(t *testing.T) {
+	client, transport := newPagedClient(250)
+	failing := true
+	transport.respond = func(req *http.Request) *http.Response {
+		if !failing {
+			return nil
+		}
+		res := fakeResponse("application/json", `{}`)
+		res.StatusCode = http.StatusServiceUnavailable
+		return res
+	}
+	params := increase.AccountListParams{Limit: increase.F(int64(100))}
+	expected := increase.Checkpoint{Path: "accounts", Query: "limit=100"}
+
+	iter := client.Accounts.ListAutoPaging(context.Background(), params, option.WithMaxRetries(0))
+	if iter.Next() || iter.Err() == nil {
+		t.Fatal("expected the first page to fail")
+	}
+	if checkpoint := iter.Checkpoint(); checkpoint != expected {
+		t.Fatalf("expected the checkpoint of the first page, got %+v", checkpoint)
+	}
+
+	resumed := client.Accounts.ResumeAutoPager(context.Background(), increase.Checkpoint{Path: "accounts", Query: "limit=100", Cursor: "100", Index: 50}, option.WithMaxRetries(0))
+	if resumed.Next() || resumed.Err() == nil {
+		t.Fatal("expected the resumed page to fail")
+	}
+	checkpoint := resumed.Checkpoint()
+	if checkpoint != (increase.Checkpoint{Path: "accounts", Query: "limit=100", Cursor: "100", Index: 50}) {
+		t.Fatalf("expected the checkpoint resumed from, got %+v", checkpoint)
+	}
+
+	failing = false
+	resumed = client.Accounts.ResumeAutoPager(context.Background(), checkpoint)
+	n := 150
+	for resumed.Next() {
+		n++
+	}
+	if resumed.Err() != nil || n != 250 {
+		t.Fatalf("expected to resume once the API recovers, got %d accounts: %v", n, resumed.Err())
+	}
+}
@@ -71,7 +71,7 @@ func (r *CheckTransferService) List(ctx context.Context, query CheckTransferList
 
 // List Check Transfers
 func (r *CheckTransferService) ListAutoPaging(ctx context.Context, query CheckTransferListParams, opts ...option.RequestOption) *shared.PageAutoPager[CheckTransfer] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Approve a Check Transfer
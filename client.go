@@ -65,6 +65,22 @@ func NewClient(opts ...option.RequestOption) (r *Client) {
 	}
 	opts = append(defaults, opts...)
 
+	return newClient(opts)
+}
+
+// WithOptions returns a copy of the client whose services apply the given
+// options after the client's own, for example to use a different API key, base
+// URL or timeout. The copy is cheap to create and shares the client's
+// [http.Client] unless the options replace it.
+func (r *Client) WithOptions(opts ...option.RequestOption) *Client {
+	return newClient(append(r.Options[:len(r.Options):len(r.Options)], opts...))
+}
+
+func newClient(opts []option.RequestOption) (r *Client) {
+	// Clip the options, so that services appending to them never share a
+	// backing array.
+	opts = opts[:len(opts):len(opts)]
+
 	r = &Client{Options: opts}
 
 	r.Accounts = NewAccountService(opts...)
@@ -103,7 +119,7 @@ func NewClient(opts ...option.RequestOption) (r *Client) {
 	r.CheckDeposits = NewCheckDepositService(opts...)
 	r.RoutingNumbers = NewRoutingNumberService(opts...)
 	r.AccountStatements = NewAccountStatementService(opts...)
-	r.Simulations = NewSimulationService(opts...)
+	r.Simulations = NewSimulationService(append(opts, requireSandbox)...)
 	r.PhysicalCards = NewPhysicalCardService(opts...)
 	r.CardPayments = NewCardPaymentService(opts...)
 
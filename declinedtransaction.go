@@ -63,7 +63,7 @@ func (r *DeclinedTransactionService) List(ctx context.Context, query DeclinedTra
 
 // List Declined Transactions
 func (r *DeclinedTransactionService) ListAutoPaging(ctx context.Context, query DeclinedTransactionListParams, opts ...option.RequestOption) *shared.PageAutoPager[DeclinedTransaction] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Declined Transactions are refused additions and removals of money from your bank
@@ -63,7 +63,7 @@ func (r *DigitalWalletTokenService) List(ctx context.Context, query DigitalWalle
 
 // List Digital Wallet Tokens
 func (r *DigitalWalletTokenService) ListAutoPaging(ctx context.Context, query DigitalWalletTokenListParams, opts ...option.RequestOption) *shared.PageAutoPager[DigitalWalletToken] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // A Digital Wallet Token is created when a user adds a Card to their Apple Pay or
@@ -62,7 +62,7 @@ func (r *DocumentService) List(ctx context.Context, query DocumentListParams, op
 
 // List Documents
 func (r *DocumentService) ListAutoPaging(ctx context.Context, query DocumentListParams, opts ...option.RequestOption) *shared.PageAutoPager[Document] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Increase generates certain documents / forms automatically for your application;
@@ -74,7 +74,7 @@ func (r *EntityService) List(ctx context.Context, query EntityListParams, opts .
 
 // List Entities
 func (r *EntityService) ListAutoPaging(ctx context.Context, query EntityListParams, opts ...option.RequestOption) *shared.PageAutoPager[Entity] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Archive an Entity
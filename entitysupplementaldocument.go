@@ -63,7 +63,7 @@ func (r *EntitySupplementalDocumentService) List(ctx context.Context, query Enti
 
 // List Entity Supplemental Document Submissions
 func (r *EntitySupplementalDocumentService) ListAutoPaging(ctx context.Context, query EntitySupplementalDocumentListParams, opts ...option.RequestOption) *shared.PageAutoPager[SupplementalDocument] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Supplemental Documents are uploaded files connected to an Entity during
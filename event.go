@@ -62,7 +62,7 @@ func (r *EventService) List(ctx context.Context, query EventListParams, opts ...
 
 // List Events
 func (r *EventService) ListAutoPaging(ctx context.Context, query EventListParams, opts ...option.RequestOption) *shared.PageAutoPager[Event] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Events are records of things that happened to objects at Increase. Events are
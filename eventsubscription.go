@@ -79,7 +79,7 @@ func (r *EventSubscriptionService) List(ctx context.Context, query EventSubscrip
 
 // List Event Subscriptions
 func (r *EventSubscriptionService) ListAutoPaging(ctx context.Context, query EventSubscriptionListParams, opts ...option.RequestOption) *shared.PageAutoPager[EventSubscription] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Webhooks are event notifications we send to you by HTTPS POST requests. Event
@@ -70,7 +70,7 @@ func (r *ExportService) List(ctx context.Context, query ExportListParams, opts .
 
 // List Exports
 func (r *ExportService) ListAutoPaging(ctx context.Context, query ExportListParams, opts ...option.RequestOption) *shared.PageAutoPager[Export] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Exports are batch summaries of your Increase data. You can make them from the
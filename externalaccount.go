@@ -79,7 +79,7 @@ func (r *ExternalAccountService) List(ctx context.Context, query ExternalAccount
 
 // List External Accounts
 func (r *ExternalAccountService) ListAutoPaging(ctx context.Context, query ExternalAccountListParams, opts ...option.RequestOption) *shared.PageAutoPager[ExternalAccount] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // External Accounts represent accounts at financial institutions other than
@@ -0,0 +1,129 @@
+package increase_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/increase/increase-go"
+	"github.com/increase/increase-go/option"
+)
+
+// fakeTransport is a fake of the API, which records the queries it receives,
+// and the host and Authorization header they were sent with. It serves its items as a list, newest first, filtered by created_at and in
+// pages of the requested limit. Requests that respond answers are not listed.
+type fakeTransport struct {
+	// The page size of requests without a limit, 100 if unset.
+	pageSize int
+	// respond answers a request instead of the list, if it returns a response.
+	respond func(req *http.Request) *http.Response
+
+	mu      sync.Mutex
+	items   []fakeItem
+	queries []string
+	hosts   []string
+}
+
+type fakeItem struct {
+	id        string
+	createdAt time.Time
+}
+
+func newFakeClient(transport *fakeTransport, opts ...option.RequestOption) *increase.Client {
+	return increase.NewClient(append([]option.RequestOption{
+		option.WithAPIKey("My API Key"),
+		option.WithHTTPClient(&http.Client{Transport: transport}),
+	}, opts...)...)
+}
+
+// add lists an item, after the items created at the same time.
+func (t *fakeTransport) add(id string, createdAt time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	i := sort.Search(len(t.items), func(i int) bool { return t.items[i].createdAt.Before(createdAt) })
+	t.items = append(t.items, fakeItem{})
+	copy(t.items[i+1:], t.items[i:])
+	t.items[i] = fakeItem{id, createdAt}
+}
+
+func (t *fakeTransport) requests() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.queries)
+}
+
+func (t *fakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.queries = append(t.queries, req.URL.RawQuery)
+	t.hosts = append(t.hosts, req.URL.Host+" "+req.Header.Get("Authorization"))
+	t.mu.Unlock()
+	if t.respond != nil {
+		if res := t.respond(req); res != nil {
+			return res, nil
+		}
+	}
+
+	query := req.URL.Query()
+	var filters []func(time.Time) bool
+	for key, keep := range map[string]func(createdAt, filter time.Time) bool{
+		"created_at.after":        time.Time.After,
+		"created_at.on_or_after":  func(createdAt, filter time.Time) bool { return !createdAt.Before(filter) },
+		"created_at.before":       time.Time.Before,
+		"created_at.on_or_before": func(createdAt, filter time.Time) bool { return !createdAt.After(filter) },
+	} {
+		if !query.Has(key) {
+			continue
+		}
+		filter, err := time.Parse(time.RFC3339Nano, query.Get(key))
+		if err != nil {
+			return nil, err
+		}
+		keep := keep
+		filters = append(filters, func(createdAt time.Time) bool { return keep(createdAt, filter) })
+	}
+	t.mu.Lock()
+	var matching []fakeItem
+items:
+	for _, item := range t.items {
+		for _, keep := range filters {
+			if !keep(item.createdAt) {
+				continue items
+			}
+		}
+		matching = append(matching, item)
+	}
+	t.mu.Unlock()
+
+	start, _ := strconv.Atoi(query.Get("cursor"))
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil {
+		limit = t.pageSize
+	}
+	if limit == 0 {
+		limit = 100
+	}
+	end := min(start+limit, len(matching))
+	var data []string
+	for _, item := range matching[start:end] {
+		data = append(data, fmt.Sprintf(`{"id":%q,"created_at":%q}`, item.id, item.createdAt.Format(time.RFC3339Nano)))
+	}
+	next := "null"
+	if end < len(matching) {
+		next = fmt.Sprintf(`"%d"`, end)
+	}
+	return fakeResponse("application/json", fmt.Sprintf(`{"data":[%s],"next_cursor":%s}`, strings.Join(data, ","), next)), nil
+}
+
+func fakeResponse(contentType string, body string) *http.Response {
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		Header:        http.Header{"Content-Type": {contentType}},
+		ContentLength: int64(len(body)),
+		Body:          io.NopCloser(strings.NewReader(body)),
+	}
+}
@@ -76,7 +76,7 @@ func (r *FileService) List(ctx context.Context, query FileListParams, opts ...op
 
 // List Files
 func (r *FileService) ListAutoPaging(ctx context.Context, query FileListParams, opts ...option.RequestOption) *shared.PageAutoPager[File] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Files are objects that represent a file hosted on Increase's servers. The file
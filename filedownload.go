@@ -0,0 +1,86 @@
+package increase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/increase/increase-go/internal/requestconfig"
+	"github.com/increase/increase-go/option"
+)
+
+// Open returns the contents of the File, such as an export or a statement PDF,
+// streamed from its download URL. If the connection breaks, the download is
+// resumed where it left off. Reading fails with [io.ErrUnexpectedEOF] if the
+// contents turn out shorter than announced. The caller must close the returned
+// reader.
+func (r *FileService) Open(ctx context.Context, fileID string, opts ...option.RequestOption) (io.ReadCloser, error) {
+	file, err := r.Get(ctx, fileID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if file.DownloadURL == "" {
+		return nil, fmt.Errorf("file %s has no download URL", fileID)
+	}
+
+	var res *http.Response
+	var body io.ReadCloser
+	opts = append(r.Options[:], opts...)
+	opts = append(opts,
+		option.WithHeader("Accept", "*/*"),
+		option.WithResponseInto(&res),
+		withoutForeignAuthorization,
+	)
+	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, file.DownloadURL, nil, &body, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &sizedReader{ReadCloser: body, remaining: res.ContentLength}, nil
+}
+
+// Download writes the contents of the File to w, as streamed by [FileService.Open],
+// and returns the number of bytes written.
+func (r *FileService) Download(ctx context.Context, fileID string, w io.Writer, opts ...option.RequestOption) (int64, error) {
+	body, err := r.Open(ctx, fileID, opts...)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+	return io.Copy(w, body)
+}
+
+// withoutForeignAuthorization keeps the API key from being sent along to a
+// download URL on another host, such as a storage provider.
+func withoutForeignAuthorization(r *requestconfig.RequestConfig) error {
+	u := r.Request.URL
+	if r.BaseURL != nil {
+		u = r.BaseURL.ResolveReference(u)
+	}
+	if r.BaseURL == nil || u.Host != r.BaseURL.Host {
+		r.Request.Header.Del("Authorization")
+	}
+	return nil
+}
+
+// sizedReader fails with io.ErrUnexpectedEOF if the body ends before the number
+// of bytes given by its Content-Length. A negative remaining means the length
+// is unknown.
+type sizedReader struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (r *sizedReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if r.remaining >= 0 {
+		r.remaining -= int64(n)
+		if err == io.EOF && r.remaining > 0 {
+			return n, io.ErrUnexpectedEOF
+		}
+		if r.remaining < 0 {
+			return n, fmt.Errorf("file contents are longer than their Content-Length")
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,52 @@
+package increase_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/increase/increase-go"
+)
+
+// newFileClient returns a client for which a file can be downloaded, with the
+// given contents and Content-Length.
+func newFileClient(contents string, contentLength int64) (*increase.Client, *fakeTransport) {
+	transport := &fakeTransport{respond: func(req *http.Request) *http.Response {
+		if req.URL.Host == "api.increase.com" {
+			return fakeResponse("application/json", `{"id":"file_makxrc67oh9l6sg7w9yc","download_url":"https://files.example.com/statement.pdf?signature=abc"}`)
+		}
+		res := fakeResponse("application/pdf", contents)
+		res.ContentLength = contentLength
+		return res
+	}}
+	return newFakeClient(transport), transport
+}
+
+func TestFileDownload(t *testing.T) {
+	client, transport := newFileClient("%PDF-1.4", 8)
+
+	var buf bytes.Buffer
+	n, err := client.Files.Download(context.Background(), "file_makxrc67oh9l6sg7w9yc", &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 8 || buf.String() != "%PDF-1.4" {
+		t.Fatalf("expected the file contents, got %d bytes: %q", n, buf.String())
+	}
+	expected := []string{"api.increase.com Bearer My API Key", "files.example.com "}
+	if strings.Join(transport.hosts, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected the API key to only be sent to the API, got %v", transport.hosts)
+	}
+}
+
+func TestFileDownloadVerifiesSize(t *testing.T) {
+	client, _ := newFileClient("%PDF", 8)
+	_, err := client.Files.Download(context.Background(), "file_makxrc67oh9l6sg7w9yc", io.Discard)
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected io.ErrUnexpectedEOF for a truncated file, got %v", err)
+	}
+}
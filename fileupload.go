@@ -0,0 +1,142 @@
+package increase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/increase/increase-go/internal/apiform"
+	"github.com/increase/increase-go/internal/param"
+	"github.com/increase/increase-go/internal/upload"
+	"github.com/increase/increase-go/option"
+)
+
+// MarshalMultipartStream encodes the params while the request is sent, so the
+// contents of File are never held in memory. The request can be retried if File
+// is seekable, such as an [*os.File], or was created with [ReopenableFile].
+func (r FileNewParams) MarshalMultipartStream() (*apiform.Stream, error) {
+	return apiform.NewStream(r)
+}
+
+// ReopenableFile returns a reader for [FileNewParams.File] that calls open for
+// every attempt of the request, so that an upload from a source that can't seek,
+// such as a file in object storage, can still be retried. The name is used as
+// the filename of the upload; size, if known, sets the Content-Length of the
+// request and should otherwise be -1.
+func ReopenableFile(name string, size int64, open func() (io.ReadCloser, error)) io.Reader {
+	return apiform.NewReopenableReader(name, size, open)
+}
+
+// FileParam returns a reader for [FileNewParams.File] that is uploaded with the
+// given filename and content type. By default, the filename is taken from the
+// reader's Name method, if any, and the content type is
+// application/octet-stream.
+func FileParam(reader io.Reader, filename string, contentType string) io.Reader {
+	return &apiform.File{Reader: reader, Filename: filename, ContentType: contentType}
+}
+
+// ErrInvalidFile matches every [*FileValidationError] with errors.Is.
+var ErrInvalidFile = errors.New("file is not valid for its purpose")
+
+// FileValidationError is returned, without sending the request, by
+// [FileService.UploadPath] and [FileService.UploadBytes] when the file doesn't
+// meet a documented requirement of its purpose, such as the dimensions of
+// digital wallet artwork.
+type FileValidationError struct {
+	Purpose FileNewParamsPurpose
+	// Why the file is not valid.
+	Reason string
+}
+
+func (e *FileValidationError) Error() string {
+	return fmt.Sprintf("file is not valid for purpose %s: %s", e.Purpose, e.Reason)
+}
+
+func (e *FileValidationError) Is(target error) bool {
+	return target == ErrInvalidFile
+}
+
+// FileUploadParams are the params of [FileService.UploadPath] and
+// [FileService.UploadBytes].
+type FileUploadParams struct {
+	// What the File will be used for in Increase's systems.
+	Purpose param.Field[FileNewParamsPurpose]
+	// The description you choose to give the File.
+	Description param.Field[string]
+	// The filename of the upload. By default, the base name of the path, or
+	// "anonymous_file" with the extension of the detected content type.
+	Filename string
+	// The content type of the upload. By default, it is detected from the
+	// contents, or else from the extension of the filename.
+	ContentType string
+	// SkipValidation uploads the file without checking it first. The API still
+	// validates it.
+	SkipValidation bool
+	// OnWarning, if set, is called when the file doesn't follow a recommendation
+	// for its purpose that is not a documented requirement of the API, such as
+	// the resolution of check images. The file is uploaded anyway.
+	OnWarning func(warning string)
+}
+
+// UploadPath creates a File from the file at the given path, after checking that
+// it meets the documented requirements of its purpose. The file is streamed,
+// and is read again if the request is retried.
+func (r *FileService) UploadPath(ctx context.Context, path string, params FileUploadParams, opts ...option.RequestOption) (*File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if params.Filename == "" {
+		params.Filename = filepath.Base(path)
+	}
+	return r.upload(ctx, f, stat.Size(), params, opts...)
+}
+
+// UploadBytes creates a File with the given contents, after checking that they
+// meet the documented requirements of its purpose.
+func (r *FileService) UploadBytes(ctx context.Context, data []byte, params FileUploadParams, opts ...option.RequestOption) (*File, error) {
+	return r.upload(ctx, bytes.NewReader(data), int64(len(data)), params, opts...)
+}
+
+// extensions are given to the filename of uploads that don't have one.
+var extensions = map[string]string{
+	"application/pdf": ".pdf",
+	"image/gif":       ".gif",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+}
+
+func (r *FileService) upload(ctx context.Context, reader io.ReadSeeker, size int64, params FileUploadParams, opts ...option.RequestOption) (*File, error) {
+	info, err := upload.Inspect(reader, size, params.Filename)
+	if err != nil {
+		return nil, err
+	}
+	if reason, advisory := upload.Check(string(params.Purpose.Value), info); reason != "" && !params.SkipValidation {
+		if !advisory {
+			return nil, &FileValidationError{Purpose: params.Purpose.Value, Reason: reason}
+		}
+		if params.OnWarning != nil {
+			params.OnWarning(reason)
+		}
+	}
+	if params.ContentType == "" {
+		params.ContentType = info.ContentType
+	}
+	if params.Filename == "" {
+		params.Filename = "anonymous_file" + extensions[info.ContentType]
+	}
+	return r.New(ctx, FileNewParams{
+		File:        F(FileParam(reader, params.Filename, params.ContentType)),
+		Purpose:     params.Purpose,
+		Description: params.Description,
+	}, opts...)
+}
@@ -0,0 +1,157 @@
+package increase_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/increase/increase-go"
+	"github.com/increase/increase-go/option"
+)
+
+type uploadTransport struct {
+	parts map[string]*multipart.Part
+	files map[string]string
+}
+
+func (t *uploadTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+	reader := multipart.NewReader(req.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		contents, _ := io.ReadAll(part)
+		t.parts[part.FormName()] = part
+		t.files[part.FormName()] = string(contents)
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"id":"file_makxrc67oh9l6sg7w9yc"}`)),
+	}, nil
+}
+
+func newUploadClient() (*increase.Client, *uploadTransport) {
+	transport := &uploadTransport{parts: map[string]*multipart.Part{}, files: map[string]string{}}
+	client := increase.NewClient(
+		option.WithAPIKey("My API Key"),
+		option.WithHTTPClient(&http.Client{Transport: transport}),
+	)
+	return client, transport
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestFileUploadPath(t *testing.T) {
+	artwork := encodePNG(t, 1536, 969)
+	path := filepath.Join(t.TempDir(), "artwork.png")
+	if err := os.WriteFile(path, artwork, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client, transport := newUploadClient()
+	file, err := client.Files.UploadPath(context.Background(), path, increase.FileUploadParams{
+		Purpose: increase.F(increase.FileNewParamsPurposeDigitalWalletArtwork),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.ID != "file_makxrc67oh9l6sg7w9yc" {
+		t.Fatalf("expected the created File, got %+v", file)
+	}
+	part := transport.parts["file"]
+	if part.FileName() != "artwork.png" || part.Header.Get("Content-Type") != "image/png" {
+		t.Errorf("expected the filename and content type to be set, got %q and %q", part.FileName(), part.Header.Get("Content-Type"))
+	}
+	if transport.files["file"] != string(artwork) || transport.files["purpose"] != "digital_wallet_artwork" {
+		t.Errorf("expected the file and purpose to be uploaded")
+	}
+}
+
+func TestFileUploadBytes(t *testing.T) {
+	client, transport := newUploadClient()
+	_, err := client.Files.UploadBytes(context.Background(), []byte("%PDF-1.4\n"), increase.FileUploadParams{
+		Purpose: increase.F(increase.FileNewParamsPurposeTrustFormationDocument),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	part := transport.parts["file"]
+	if part.FileName() != "anonymous_file.pdf" || part.Header.Get("Content-Type") != "application/pdf" {
+		t.Errorf("expected a default filename and the detected content type, got %q and %q", part.FileName(), part.Header.Get("Content-Type"))
+	}
+}
+
+func TestFileUploadValidatesPurpose(t *testing.T) {
+	client, transport := newUploadClient()
+	_, err := client.Files.UploadBytes(context.Background(), encodePNG(t, 100, 100), increase.FileUploadParams{
+		Purpose: increase.F(increase.FileNewParamsPurposeDigitalWalletArtwork),
+	})
+	var validationErr *increase.FileValidationError
+	if !errors.Is(err, increase.ErrInvalidFile) || !errors.As(err, &validationErr) {
+		t.Fatalf("expected a FileValidationError, got %v", err)
+	}
+	if validationErr.Reason != "the image is 100x100 pixels, but must be 1536x969 pixels" {
+		t.Errorf("unexpected reason %q", validationErr.Reason)
+	}
+	if len(transport.parts) != 0 {
+		t.Errorf("expected no request to be sent")
+	}
+}
+
+func TestFileUploadWarnsOnRecommendations(t *testing.T) {
+	client, transport := newUploadClient()
+	var warnings []string
+	_, err := client.Files.UploadBytes(context.Background(), encodePNG(t, 600, 250), increase.FileUploadParams{
+		Purpose:   increase.F(increase.FileNewParamsPurposeCheckImageFront),
+		OnWarning: func(warning string) { warnings = append(warnings, warning) },
+	})
+	if err != nil {
+		t.Fatalf("expected a recommendation not to stop the upload, got %v", err)
+	}
+	if len(warnings) != 1 || warnings[0] != "the image is 600x250 pixels, but must be at least 900x375 pixels" {
+		t.Errorf("expected a warning about the dimensions, got %q", warnings)
+	}
+	if len(transport.parts) == 0 {
+		t.Errorf("expected the file to be uploaded")
+	}
+}
+
+func TestFileUploadSkipValidation(t *testing.T) {
+	client, transport := newUploadClient()
+	_, err := client.Files.UploadBytes(context.Background(), encodePNG(t, 100, 100), increase.FileUploadParams{
+		Purpose:        increase.F(increase.FileNewParamsPurposeDigitalWalletArtwork),
+		SkipValidation: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transport.parts["file"].Header.Get("Content-Type") != "image/png" {
+		t.Errorf("expected the file to be uploaded with its detected content type")
+	}
+}
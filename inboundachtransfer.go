@@ -63,7 +63,7 @@ func (r *InboundACHTransferService) List(ctx context.Context, query InboundACHTr
 
 // List Inbound ACH Transfers
 func (r *InboundACHTransferService) ListAutoPaging(ctx context.Context, query InboundACHTransferListParams, opts ...option.RequestOption) *shared.PageAutoPager[InboundACHTransfer] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Decline an Inbound ACH Transfer
@@ -62,7 +62,7 @@ func (r *InboundWireDrawdownRequestService) List(ctx context.Context, query Inbo
 
 // List Inbound Wire Drawdown Requests
 func (r *InboundWireDrawdownRequestService) ListAutoPaging(ctx context.Context, query InboundWireDrawdownRequestListParams, opts ...option.RequestOption) *shared.PageAutoPager[InboundWireDrawdownRequest] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Inbound wire drawdown requests are requests from someone else to send them a
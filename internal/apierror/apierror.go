@@ -3,10 +3,12 @@
 package apierror
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httputil"
+	"strings"
 
 	"github.com/increase/increase-go/internal/apijson"
 )
@@ -27,6 +29,23 @@ type Error struct {
 	StatusCode int
 	Request    *http.Request
 	Response   *http.Response
+	// The raw response body, captured when the error was created.
+	Body []byte `json:"-"`
+}
+
+// NewError returns the error for a response with a status code of 400 or more,
+// whose body has already been read. The response's body is replaced so that it
+// can still be read.
+func NewError(req *http.Request, res *http.Response, body []byte) *Error {
+	r := &Error{Request: req, Response: res, Body: body}
+	if res != nil {
+		r.StatusCode = res.StatusCode
+		res.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	// A body that isn't a JSON error object, such as the HTML page of a proxy,
+	// leaves the fields empty; the status code and raw body remain.
+	r.UnmarshalJSON(body)
+	return r
 }
 
 // errorJSON contains the JSON metadata for the struct [Error]
@@ -46,12 +65,62 @@ func (r *Error) UnmarshalJSON(data []byte) (err error) {
 	return apijson.UnmarshalRoot(data, r)
 }
 
+// Error returns a concise message made of the status code, title, detail and
+// type of the error, such as `400 Bad Request: Missing param "name": Looks like
+// "naem" may have been a typo? (missing_param)`. Format the error with %+v to
+// include the request method, URL and response body as well.
 func (r *Error) Error() string {
-	body, _ := io.ReadAll(r.Response.Body)
-	return fmt.Sprintf("%s \"%s\": %d %s %s", r.Request.Method, r.Request.URL, r.Response.StatusCode, http.StatusText(r.Response.StatusCode), string(body))
+	if r == nil {
+		return "<nil>"
+	}
+	var b strings.Builder
+	status := r.statusCode()
+	fmt.Fprintf(&b, "%d %s", status, http.StatusText(status))
+	if r.Title != "" {
+		b.WriteString(": " + r.Title)
+	}
+	if r.Detail != "" {
+		b.WriteString(": " + r.Detail)
+	}
+	if r.Type != "" {
+		fmt.Fprintf(&b, " (%s)", r.Type)
+	}
+	return b.String()
+}
+
+// Format implements [fmt.Formatter]. The %+v verb renders the message of Error,
+// followed by the request method and URL and the raw response body.
+func (r *Error) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('+'):
+		io.WriteString(f, r.Error())
+		if r == nil {
+			return
+		}
+		if r.Request != nil {
+			fmt.Fprintf(f, "\n%s %s", r.Request.Method, r.Request.URL)
+		}
+		if len(r.Body) != 0 {
+			fmt.Fprintf(f, "\n%s", r.Body)
+		}
+	case verb == 'q':
+		fmt.Fprintf(f, "%q", r.Error())
+	default:
+		io.WriteString(f, r.Error())
+	}
+}
+
+func (r *Error) statusCode() int {
+	if r.StatusCode == 0 && r.Response != nil {
+		return r.Response.StatusCode
+	}
+	return r.StatusCode
 }
 
 func (r *Error) DumpRequest(body bool) []byte {
+	if r == nil || r.Request == nil {
+		return nil
+	}
 	if r.Request.GetBody != nil {
 		r.Request.Body, _ = r.Request.GetBody()
 	}
@@ -60,6 +129,14 @@ func (r *Error) DumpRequest(body bool) []byte {
 }
 
 func (r *Error) DumpResponse(body bool) []byte {
+	if r == nil || r.Response == nil {
+		return nil
+	}
+	// Dumping consumes the body, so it is restored from the captured copy every
+	// time.
+	if r.Body != nil {
+		r.Response.Body = io.NopCloser(bytes.NewReader(r.Body))
+	}
 	out, _ := httputil.DumpResponse(r.Response, body)
 	return out
 }
@@ -67,11 +144,29 @@ func (r *Error) DumpResponse(body bool) []byte {
 type ErrorStatus int64
 
 const (
+	ErrorStatus400 ErrorStatus = 400
+	ErrorStatus401 ErrorStatus = 401
+	ErrorStatus403 ErrorStatus = 403
+	ErrorStatus404 ErrorStatus = 404
 	ErrorStatus409 ErrorStatus = 409
+	ErrorStatus429 ErrorStatus = 429
+	ErrorStatus500 ErrorStatus = 500
 )
 
 type ErrorType string
 
 const (
+	ErrorTypeAPIMethodNotFoundError             ErrorType = "api_method_not_found_error"
+	ErrorTypeEnvironmentMismatchError           ErrorType = "environment_mismatch_error"
+	ErrorTypeIdempotencyKeyAlreadyUsedError     ErrorType = "idempotency_key_already_used_error"
+	ErrorTypeInsufficientPermissionsError       ErrorType = "insufficient_permissions_error"
+	ErrorTypeInternalServerError                ErrorType = "internal_server_error"
+	ErrorTypeInvalidAPIKeyError                 ErrorType = "invalid_api_key_error"
+	ErrorTypeInvalidOperationError              ErrorType = "invalid_operation_error"
+	ErrorTypeInvalidParametersError             ErrorType = "invalid_parameters_error"
+	ErrorTypeMalformedRequestError              ErrorType = "malformed_request_error"
+	ErrorTypeObjectNotFoundError                ErrorType = "object_not_found_error"
+	ErrorTypePrivateFeatureError                ErrorType = "private_feature_error"
+	ErrorTypeRateLimitedError                   ErrorType = "rate_limited_error"
 	ErrorTypeUniqueIdentifierAlreadyExistsError ErrorType = "unique_identifier_already_exists_error"
 )
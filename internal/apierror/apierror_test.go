@@ -0,0 +1,48 @@
+package apierror
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestErrorFormattingIsRepeatable(t *testing.T) {
+	body := `{"type":"invalid_parameters_error","title":"Missing param \"name\"","detail":"Looks like \"naem\" may have been a typo?","status":400}`
+	req, _ := http.NewRequest(http.MethodPost, "https://api.increase.com/accounts", nil)
+	res := &http.Response{StatusCode: 400, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}
+	contents, _ := io.ReadAll(res.Body)
+	err := NewError(req, res, contents)
+
+	expected := `400 Bad Request: Missing param "name": Looks like "naem" may have been a typo? (invalid_parameters_error)`
+	for i := 0; i < 2; i++ {
+		if err.Error() != expected {
+			t.Fatalf("expected %q, got %q", expected, err.Error())
+		}
+		verbose := fmt.Sprintf("%+v", err)
+		if !strings.Contains(verbose, "POST https://api.increase.com/accounts") || !strings.Contains(verbose, body) {
+			t.Fatalf("expected the verbose format to contain the request and body, got %q", verbose)
+		}
+		if dump := string(err.DumpResponse(true)); !strings.Contains(dump, body) {
+			t.Fatalf("expected the dumped response to contain the body, got %q", dump)
+		}
+	}
+	if read, _ := io.ReadAll(res.Body); string(read) != body {
+		t.Fatalf("expected the response body to remain readable, got %q", read)
+	}
+}
+
+func TestErrorWithoutResponse(t *testing.T) {
+	err := &Error{StatusCode: 502}
+	if err.Error() != "502 Bad Gateway" || fmt.Sprintf("%+v", err) != "502 Bad Gateway" {
+		t.Fatalf("expected a message from the status code alone, got %q", err.Error())
+	}
+	if err.DumpRequest(true) != nil || err.DumpResponse(true) != nil {
+		t.Fatal("expected nothing to dump")
+	}
+	var nilErr *Error
+	if nilErr.Error() != "<nil>" {
+		t.Fatalf("expected a nil error to be formatted, got %q", nilErr.Error())
+	}
+}
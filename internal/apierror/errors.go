@@ -0,0 +1,123 @@
+package apierror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"reflect"
+)
+
+// ErrTimeout matches, with errors.Is, every error caused by a request running
+// out of time, whether because of the per-attempt timeout or the deadline of
+// the request's context.
+var ErrTimeout = errors.New("request timed out")
+
+// ErrConnection matches every [*ConnectionError] with errors.Is.
+var ErrConnection = errors.New("connection error")
+
+// ConnectionError is returned when no response was received for a request, for
+// example because the API could not be reached, or the request timed out or was
+// canceled. Errors returned by a middleware are not wrapped.
+type ConnectionError struct {
+	Request *http.Request
+	Err     error
+}
+
+func (e *ConnectionError) Error() string {
+	if e.Request == nil {
+		return fmt.Sprintf("connection error: %v", e.Err)
+	}
+	return fmt.Sprintf("%s \"%s\": %v", e.Request.Method, e.Request.URL, e.Err)
+}
+
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ConnectionError) Is(target error) bool {
+	switch target {
+	case ErrConnection:
+		return true
+	case ErrTimeout:
+		return e.Timeout()
+	}
+	return false
+}
+
+// Timeout reports whether the request ran out of time.
+func (e *ConnectionError) Timeout() bool {
+	if errors.Is(e.Err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(e.Err, &netErr) && netErr.Timeout()
+}
+
+// DecodeError is returned when a response body could not be decoded into the
+// destination.
+type DecodeError struct {
+	// The raw response body.
+	Body []byte
+	// The type the body was decoded into.
+	Type reflect.Type
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("error decoding response into %v: %v", e.Type, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// EncodeError is returned, without sending the request, when the request
+// params could not be encoded.
+type EncodeError struct {
+	// The type of the params.
+	Type reflect.Type
+	Err  error
+}
+
+func (e *EncodeError) Error() string {
+	return fmt.Sprintf("error encoding %v: %v", e.Type, e.Err)
+}
+
+func (e *EncodeError) Unwrap() error {
+	return e.Err
+}
+
+// IsRetryable reports whether the request that failed with err may succeed when
+// sent again: connection errors, timeouts, and API errors with a 408, 409, 429
+// or 5xx status code, unless the API said otherwise with the `x-should-retry`
+// header. Errors that have a `Retryable() bool` method decide for themselves.
+func IsRetryable(err error) bool {
+	var retryable interface{ Retryable() bool }
+	if errors.As(err, &retryable) {
+		return retryable.Retryable()
+	}
+
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		if apiErr.Response != nil {
+			switch apiErr.Response.Header.Get("x-should-retry") {
+			case "true":
+				return true
+			case "false":
+				return false
+			}
+		}
+		return apiErr.StatusCode == http.StatusRequestTimeout ||
+			apiErr.StatusCode == http.StatusConflict ||
+			apiErr.StatusCode == http.StatusTooManyRequests ||
+			apiErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	// The caller gave up on the request.
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	return errors.Is(err, ErrConnection) || errors.Is(err, ErrTimeout)
+}
@@ -0,0 +1,163 @@
+package apierror
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// As makes the error reachable with errors.As as the typed error matching its
+// Type, such as [*ObjectNotFoundError] for "object_not_found_error".
+func (r *Error) As(target interface{}) bool {
+	switch target := target.(type) {
+	case **APIMethodNotFoundError:
+		return as(r, ErrorTypeAPIMethodNotFoundError, target, &APIMethodNotFoundError{apiError: r})
+	case **EnvironmentMismatchError:
+		return as(r, ErrorTypeEnvironmentMismatchError, target, &EnvironmentMismatchError{apiError: r})
+	case **IdempotencyKeyAlreadyUsedError:
+		return as(r, ErrorTypeIdempotencyKeyAlreadyUsedError, target, &IdempotencyKeyAlreadyUsedError{apiError: r})
+	case **InsufficientPermissionsError:
+		return as(r, ErrorTypeInsufficientPermissionsError, target, &InsufficientPermissionsError{apiError: r})
+	case **InternalServerError:
+		return as(r, ErrorTypeInternalServerError, target, &InternalServerError{apiError: r})
+	case **InvalidAPIKeyError:
+		return as(r, ErrorTypeInvalidAPIKeyError, target, &InvalidAPIKeyError{apiError: r, Reason: r.extraString("reason")})
+	case **InvalidOperationError:
+		return as(r, ErrorTypeInvalidOperationError, target, &InvalidOperationError{apiError: r})
+	case **InvalidParametersError:
+		return as(r, ErrorTypeInvalidParametersError, target, &InvalidParametersError{apiError: r, Errors: r.parameterErrors()})
+	case **MalformedRequestError:
+		return as(r, ErrorTypeMalformedRequestError, target, &MalformedRequestError{apiError: r})
+	case **ObjectNotFoundError:
+		return as(r, ErrorTypeObjectNotFoundError, target, &ObjectNotFoundError{apiError: r})
+	case **PrivateFeatureError:
+		return as(r, ErrorTypePrivateFeatureError, target, &PrivateFeatureError{apiError: r})
+	case **RateLimitedError:
+		return as(r, ErrorTypeRateLimitedError, target, &RateLimitedError{apiError: r})
+	case **UniqueIdentifierAlreadyExistsError:
+		return as(r, ErrorTypeUniqueIdentifierAlreadyExistsError, target, &UniqueIdentifierAlreadyExistsError{apiError: r})
+	}
+	return false
+}
+
+// apiError lets the typed errors embed [*Error] without the embedded field
+// hiding its Error method.
+type apiError = Error
+
+func as[T any](r *Error, typ ErrorType, target **T, typed *T) bool {
+	if r.Type != typ {
+		return false
+	}
+	*target = typed
+	return true
+}
+
+// APIMethodNotFoundError is returned when the requested endpoint does not exist.
+type APIMethodNotFoundError struct{ *apiError }
+
+// EnvironmentMismatchError is returned when an API key for one environment, such
+// as the sandbox, is used against another.
+type EnvironmentMismatchError struct{ *apiError }
+
+// IdempotencyKeyAlreadyUsedError is returned when an idempotency key is reused
+// with different parameters. ResourceID is the ID of the resource created with
+// the key.
+type IdempotencyKeyAlreadyUsedError struct{ *apiError }
+
+// InsufficientPermissionsError is returned when the API key is not allowed to
+// perform the request.
+type InsufficientPermissionsError struct{ *apiError }
+
+// InternalServerError is returned when the API failed unexpectedly.
+type InternalServerError struct{ *apiError }
+
+// InvalidAPIKeyError is returned when the API key is missing, malformed,
+// expired or deleted.
+type InvalidAPIKeyError struct {
+	*apiError
+	// Why the API key was rejected, such as "deleted_credential" or
+	// "expired_credential".
+	Reason string
+}
+
+// InvalidOperationError is returned when the request is not allowed in the
+// current state of the resource, such as cancelling a submitted transfer.
+type InvalidOperationError struct{ *apiError }
+
+// InvalidParametersError is returned when request parameters fail validation.
+type InvalidParametersError struct {
+	*apiError
+	// The parameters that failed validation.
+	Errors []ParameterError
+}
+
+// MalformedRequestError is returned when the request body could not be parsed.
+type MalformedRequestError struct{ *apiError }
+
+// ObjectNotFoundError is returned when the requested resource does not exist.
+type ObjectNotFoundError struct{ *apiError }
+
+// PrivateFeatureError is returned when the request uses a feature that has not
+// been enabled for the account.
+type PrivateFeatureError struct{ *apiError }
+
+// RateLimitedError is returned when too many requests were made. RetryAfter is
+// the number of seconds to wait before retrying, if given.
+type RateLimitedError struct{ *apiError }
+
+// UniqueIdentifierAlreadyExistsError is returned when a unique identifier, such
+// as a unique_identifier param, was already used. ResourceID is the ID of the
+// existing resource.
+type UniqueIdentifierAlreadyExistsError struct{ *apiError }
+
+// ParameterError describes a single parameter that failed validation.
+type ParameterError struct {
+	// The parameter, as reported by the API, such as "destination.account_number"
+	// or "entries[0].amount".
+	Field string `json:"field"`
+	// Why the parameter is invalid.
+	Message string `json:"message"`
+	// A JSON pointer (RFC 6901) to the parameter within the request body, such as
+	// "/entries/0/amount".
+	Pointer string `json:"pointer"`
+}
+
+func (r *Error) parameterErrors() []ParameterError {
+	raw, err := json.Marshal(r.Errors)
+	if err != nil {
+		return nil
+	}
+	var errs []ParameterError
+	if err := json.Unmarshal(raw, &errs); err != nil {
+		return nil
+	}
+	for i := range errs {
+		if errs[i].Pointer == "" {
+			errs[i].Pointer = jsonPointer(errs[i].Field)
+		}
+	}
+	return errs
+}
+
+// jsonPointer converts a parameter path in dot and bracket notation, such as
+// "entries[0].amount", to a JSON pointer, such as "/entries/0/amount". Paths
+// that already are JSON pointers are returned as is.
+func jsonPointer(field string) string {
+	if field == "" || strings.HasPrefix(field, "/") {
+		return field
+	}
+	segments := strings.FieldsFunc(field, func(r rune) bool { return r == '.' || r == '[' || r == ']' })
+	var b strings.Builder
+	for _, segment := range segments {
+		b.WriteByte('/')
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(segment))
+	}
+	return b.String()
+}
+
+// extraString returns a string field of the error body that is not modelled by
+// [Error], or "" if there is none.
+func (r *Error) extraString(name string) string {
+	return gjson.Get(r.JSON.raw, name).String()
+}
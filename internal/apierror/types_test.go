@@ -0,0 +1,70 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestErrorAsTypedError(t *testing.T) {
+	aerr := &Error{StatusCode: 400}
+	err := aerr.UnmarshalJSON([]byte(`{
+		"type": "invalid_parameters_error",
+		"title": "Invalid parameters",
+		"status": 400,
+		"errors": [
+			{"field": "entries[0].amount", "message": "must be positive"},
+			{"field": "destination.account_number", "message": "is required"}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrapped := fmt.Errorf("creating transfer: %w", aerr)
+
+	var notFound *ObjectNotFoundError
+	if errors.As(wrapped, &notFound) {
+		t.Fatal("expected an invalid parameters error not to match *ObjectNotFoundError")
+	}
+	var invalid *InvalidParametersError
+	if !errors.As(wrapped, &invalid) {
+		t.Fatal("expected the error to match *InvalidParametersError")
+	}
+	if invalid.Title != "Invalid parameters" || invalid.StatusCode != 400 {
+		t.Fatalf("expected the fields of the API error to be promoted, got %q and %d", invalid.Title, invalid.StatusCode)
+	}
+	expected := []ParameterError{
+		{Field: "entries[0].amount", Message: "must be positive", Pointer: "/entries/0/amount"},
+		{Field: "destination.account_number", Message: "is required", Pointer: "/destination/account_number"},
+	}
+	if !reflect.DeepEqual(invalid.Errors, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, invalid.Errors)
+	}
+}
+
+func TestErrorAsInvalidAPIKeyError(t *testing.T) {
+	aerr := &Error{StatusCode: 401}
+	if err := aerr.UnmarshalJSON([]byte(`{"type":"invalid_api_key_error","title":"Invalid API key","status":401,"reason":"expired_credential"}`)); err != nil {
+		t.Fatal(err)
+	}
+	var invalid *InvalidAPIKeyError
+	if !errors.As(aerr, &invalid) || invalid.Reason != "expired_credential" {
+		t.Fatalf("expected an *InvalidAPIKeyError with the reason, got %+v", invalid)
+	}
+}
+
+func TestJSONPointer(t *testing.T) {
+	tests := map[string]string{
+		"amount":            "/amount",
+		"entries[0].amount": "/entries/0/amount",
+		"/entries/0/amount": "/entries/0/amount",
+		"metadata.a/b":      "/metadata/a~1b",
+		"":                  "",
+	}
+	for field, expected := range tests {
+		if pointer := jsonPointer(field); pointer != expected {
+			t.Errorf("jsonPointer(%q) = %q, expected %q", field, pointer, expected)
+		}
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/textproto"
 	"path"
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -306,15 +308,47 @@ func (e *encoder) newReaderTypeEncoder() encoderFunc {
 	return func(key string, value reflect.Value, writer *multipart.Writer) error {
 		reader := value.Convert(reflect.TypeOf((*io.Reader)(nil)).Elem()).Interface().(io.Reader)
 		filename := "anonymous_file"
-		if named, ok := reader.(interface{ Name() string }); ok {
+		contentType := "application/octet-stream"
+		if file, ok := reader.(*File); ok {
+			reader = file.Reader
+			if file.ContentType != "" {
+				contentType = file.ContentType
+			}
+			if file.Filename != "" {
+				filename = file.Filename
+			}
+		}
+		if named, ok := reader.(interface{ Name() string }); ok && filename == "anonymous_file" {
 			filename = path.Base(named.Name())
 		}
-		filewriter, err := writer.CreateFormFile(key, filename)
-		io.Copy(filewriter, reader)
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(key), escapeQuotes(filename)))
+		header.Set("Content-Type", contentType)
+		filewriter, err := writer.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		if m, ok := measurements.Load(writer); ok {
+			return m.(*measurement).add(reader)
+		}
+		_, err = io.Copy(filewriter, reader)
+		if reopenable, ok := reader.(*ReopenableReader); ok {
+			if cerr := reopenable.Close(); err == nil {
+				err = cerr
+			}
+		}
 		return err
 	}
 }
 
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"", "\r", "%0D", "\n", "%0A")
+
+// escapeQuotes escapes a parameter of the Content-Disposition header, the same
+// way as [multipart.Writer.CreateFormFile].
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
 // Given a []byte of json (may either be an empty object or an object that already contains entries)
 // encode all of the entries in the map to the json byte array.
 func (e *encoder) encodeMapEntries(key string, v reflect.Value, writer *multipart.Writer) error {
@@ -1,5 +1,15 @@
 package apiform
 
+import "io"
+
 type Marshaler interface {
 	MarshalMultipart() ([]byte, string, error)
 }
+
+// File is a reader that is sent with the given filename and content type,
+// rather than with the name of the reader and as application/octet-stream.
+type File struct {
+	io.Reader
+	Filename    string
+	ContentType string
+}
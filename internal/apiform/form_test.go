@@ -96,10 +96,12 @@ func (UnionTime) union() {}
 type ReaderStruct struct {
 }
 
-var tests = map[string]struct {
+type test struct {
 	buf string
 	val interface{}
-}{
+}
+
+var tests = map[string]test{
 	"map_string": {
 		`--xxx
 Content-Disposition: form-data; name="foo"
@@ -417,8 +419,51 @@ Content-Disposition: form-data; name="union"
 	},
 }
 
+// readerTests returns the tests of values with readers, which are drained by
+// encoding, so they are built again for each run.
+func readerTests() map[string]test {
+	return map[string]test{
+		"reader": {
+			`--xxx
+Content-Disposition: form-data; name="file"; filename="anonymous_file"
+Content-Type: application/octet-stream
+
+contents
+--xxx
+Content-Disposition: form-data; name="purpose"
+
+other
+--xxx--
+`,
+			Upload{File: strings.NewReader("contents"), Purpose: "other"},
+		},
+
+		"reader_file": {
+			`--xxx
+Content-Disposition: form-data; name="file"; filename="check \"front\".png"
+Content-Type: image/png
+
+contents
+--xxx
+Content-Disposition: form-data; name="purpose"
+
+check_image_front
+--xxx--
+`,
+			Upload{File: &File{Reader: strings.NewReader("contents"), Filename: `check "front".png`, ContentType: "image/png"}, Purpose: "check_image_front"},
+		},
+	}
+}
+
 func TestEncode(t *testing.T) {
+	all := map[string]test{}
 	for name, test := range tests {
+		all[name] = test
+	}
+	for name, test := range readerTests() {
+		all[name] = test
+	}
+	for name, test := range all {
 		t.Run(name, func(t *testing.T) {
 			buf := bytes.NewBuffer(nil)
 			writer := multipart.NewWriter(buf)
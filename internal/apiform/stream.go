@@ -0,0 +1,309 @@
+package apiform
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime/multipart"
+	"sync"
+)
+
+// StreamMarshaler is implemented by params whose multipart body can be encoded
+// while it is being sent, rather than buffered in memory first.
+type StreamMarshaler interface {
+	MarshalMultipartStream() (*Stream, error)
+}
+
+// Stream is a multipart body that is encoded on the fly, as it is read. It can
+// be opened again to retry a request as long as all of its readers can be
+// rewound: they are either seekable, or a [*ReopenableReader].
+type Stream struct {
+	value       interface{}
+	boundary    string
+	contentType string
+	length      int64
+	readers     []rewinder
+
+	mu     sync.Mutex
+	opened bool
+	body   *io.PipeReader
+	done   chan struct{}
+}
+
+// NewStream measures the multipart encoding of value, without reading any of
+// its readers, so that it can be streamed.
+func NewStream(value interface{}) (*Stream, error) {
+	m := &measurement{}
+	counter := &countingWriter{}
+	writer := multipart.NewWriter(counter)
+	measurements.Store(writer, m)
+	err := MarshalRoot(value, writer)
+	measurements.Delete(writer)
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	length := counter.n + m.size
+	if m.unknownSize {
+		length = -1
+	}
+	return &Stream{
+		value:       value,
+		boundary:    writer.Boundary(),
+		contentType: writer.FormDataContentType(),
+		length:      length,
+		readers:     m.readers,
+	}, nil
+}
+
+// ContentType returns the multipart content type, including the boundary.
+func (s *Stream) ContentType() string {
+	return s.contentType
+}
+
+// ContentLength returns the length of the body, or -1 if the size of one of
+// its readers is not known.
+func (s *Stream) ContentLength() int64 {
+	return s.length
+}
+
+// Rewindable reports whether the body can be opened more than once.
+func (s *Stream) Rewindable() bool {
+	for _, r := range s.readers {
+		if r == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Open returns the body, which is encoded as it is read. Opening it again
+// abandons the previous body and rewinds the readers.
+func (s *Stream) Open() (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.opened {
+		// Wait for the previous body to stop reading before rewinding.
+		s.body.CloseWithError(errors.New("multipart body was reopened"))
+		<-s.done
+		for _, r := range s.readers {
+			if r == nil {
+				return nil, errors.New("multipart body cannot be sent again, as one of its readers cannot be rewound")
+			}
+			if err := r.rewind(); err != nil {
+				return nil, fmt.Errorf("error rewinding multipart body: %w", err)
+			}
+		}
+	}
+	s.opened = true
+
+	body, pipe := io.Pipe()
+	done := make(chan struct{})
+	s.body, s.done = body, done
+	go func() {
+		defer close(done)
+		writer := multipart.NewWriter(pipe)
+		err := writer.SetBoundary(s.boundary)
+		if err == nil {
+			err = MarshalRoot(s.value, writer)
+		}
+		if err == nil {
+			err = writer.Close()
+		}
+		pipe.CloseWithError(err)
+	}()
+	return body, nil
+}
+
+// Body returns a body that opens the stream when it is first read. Until then,
+// nothing is encoded, so a request that is never sent, for example because a
+// middleware failed it, does not leave an encoding goroutine behind.
+func (s *Stream) Body() io.ReadCloser {
+	return &lazyBody{stream: s}
+}
+
+type lazyBody struct {
+	stream *Stream
+	mu     sync.Mutex
+	body   io.ReadCloser
+	closed bool
+}
+
+func (b *lazyBody) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return 0, io.ErrClosedPipe
+	}
+	if b.body == nil {
+		body, err := b.stream.Open()
+		if err != nil {
+			b.mu.Unlock()
+			return 0, err
+		}
+		b.body = body
+	}
+	body := b.body
+	b.mu.Unlock()
+	return body.Read(p)
+}
+
+func (b *lazyBody) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	if b.body == nil {
+		return nil
+	}
+	return b.body.Close()
+}
+
+// ReopenableReader is a reader that can be sent again, when a request is
+// retried, by opening it again, such as a file by its path.
+type ReopenableReader struct {
+	name    string
+	size    int64
+	open    func() (io.ReadCloser, error)
+	current io.ReadCloser
+}
+
+// NewReopenableReader returns a reader that calls open every time it is sent.
+// The name is used as the filename of the part, and size, if not negative, to
+// compute the length of the body.
+func NewReopenableReader(name string, size int64, open func() (io.ReadCloser, error)) *ReopenableReader {
+	return &ReopenableReader{name: name, size: size, open: open}
+}
+
+func (r *ReopenableReader) Read(p []byte) (int, error) {
+	if r.current == nil {
+		current, err := r.open()
+		if err != nil {
+			return 0, err
+		}
+		r.current = current
+	}
+	return r.current.Read(p)
+}
+
+// Name returns the filename of the part.
+func (r *ReopenableReader) Name() string {
+	return r.name
+}
+
+// Close closes the currently opened reader, if any.
+func (r *ReopenableReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+	err := r.current.Close()
+	r.current = nil
+	return err
+}
+
+func (r *ReopenableReader) rewind() error {
+	return r.Close()
+}
+
+type rewinder interface {
+	rewind() error
+}
+
+type seekRewinder struct {
+	seeker io.Seeker
+	start  int64
+}
+
+func (r seekRewinder) rewind() error {
+	_, err := r.seeker.Seek(r.start, io.SeekStart)
+	return err
+}
+
+// measurements holds the measurement of the writers used by NewStream, whose
+// readers are measured rather than copied.
+var measurements sync.Map // map[*multipart.Writer]*measurement
+
+type measurement struct {
+	size        int64
+	unknownSize bool
+	// How to rewind each reader, in the order they are encoded; nil if it can't
+	// be rewound.
+	readers []rewinder
+}
+
+func (m *measurement) add(reader io.Reader) error {
+	size, err := readerSize(reader)
+	if err != nil {
+		return err
+	}
+	if size < 0 {
+		m.unknownSize = true
+	} else {
+		m.size += size
+	}
+
+	switch reader := reader.(type) {
+	case *ReopenableReader:
+		m.readers = append(m.readers, reader)
+	case io.Seeker:
+		start, err := reader.Seek(0, io.SeekCurrent)
+		if err != nil {
+			m.readers = append(m.readers, nil)
+		} else {
+			m.readers = append(m.readers, seekRewinder{seeker: reader, start: start})
+		}
+	default:
+		m.readers = append(m.readers, nil)
+	}
+	return nil
+}
+
+// readerSize returns the number of bytes left to read from the reader, or -1 if
+// it can't be known without reading it.
+func readerSize(reader io.Reader) (int64, error) {
+	switch reader := reader.(type) {
+	case *ReopenableReader:
+		return reader.size, nil
+	case interface{ Len() int }:
+		return int64(reader.Len()), nil
+	case interface {
+		io.Seeker
+		Stat() (fs.FileInfo, error)
+	}:
+		info, err := reader.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return -1, nil
+		}
+		offset, err := reader.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1, nil
+		}
+		return info.Size() - offset, nil
+	case io.Seeker:
+		offset, err := reader.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1, nil
+		}
+		end, err := reader.Seek(0, io.SeekEnd)
+		if err != nil {
+			return -1, nil
+		}
+		if _, err := reader.Seek(offset, io.SeekStart); err != nil {
+			return 0, err
+		}
+		return end - offset, nil
+	}
+	return -1, nil
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,137 @@
+package apiform
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"strings"
+	"testing"
+)
+
+type Upload struct {
+	File    io.Reader `form:"file"`
+	Purpose string    `form:"purpose"`
+}
+
+func buffered(t *testing.T, s *Stream, val interface{}) string {
+	t.Helper()
+	buf := bytes.NewBuffer(nil)
+	writer := multipart.NewWriter(buf)
+	writer.SetBoundary(s.boundary)
+	if err := MarshalRoot(val, writer); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func readStream(t *testing.T, s *Stream) string {
+	t.Helper()
+	body, err := s.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(raw)
+}
+
+func TestStreamSeekableReader(t *testing.T) {
+	file := strings.NewReader("xxcontents of the file")
+	file.Seek(2, io.SeekStart)
+	val := Upload{File: file, Purpose: "check_image_front"}
+
+	s, err := NewStream(val)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Rewindable() {
+		t.Fatal("expected a seekable reader to be rewindable")
+	}
+	if !strings.HasPrefix(s.ContentType(), "multipart/form-data; boundary=") {
+		t.Errorf("unexpected content type %q", s.ContentType())
+	}
+
+	first := readStream(t, s)
+	file.Seek(2, io.SeekStart)
+	expected := buffered(t, s, val)
+	if first != expected {
+		t.Errorf("expected the stream to match the buffered encoding\n%s\ngot\n%s", expected, first)
+	}
+	if s.ContentLength() != int64(len(expected)) {
+		t.Errorf("expected a content length of %d, got %d", len(expected), s.ContentLength())
+	}
+
+	// Abandon a partially read body, as a failed attempt would.
+	body, err := s.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.ReadFull(body, make([]byte, 10))
+	if again := readStream(t, s); again != expected {
+		t.Errorf("expected a reopened stream to start over, got\n%s", again)
+	}
+}
+
+func TestStreamUnseekableReader(t *testing.T) {
+	s, err := NewStream(Upload{File: io.MultiReader(strings.NewReader("contents")), Purpose: "other"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.ContentLength() != -1 {
+		t.Errorf("expected an unknown content length, got %d", s.ContentLength())
+	}
+	if s.Rewindable() {
+		t.Error("expected an unseekable reader not to be rewindable")
+	}
+	if raw := readStream(t, s); !strings.Contains(raw, "\r\n\r\ncontents\r\n") {
+		t.Errorf("expected the contents in the body, got\n%s", raw)
+	}
+	if _, err := s.Open(); err == nil {
+		t.Error("expected reopening to fail")
+	}
+}
+
+type trackedReader struct {
+	io.Reader
+	closed *int
+}
+
+func (r trackedReader) Close() error {
+	*r.closed++
+	return nil
+}
+
+func TestStreamReopenableReader(t *testing.T) {
+	opened, closed := 0, 0
+	file := NewReopenableReader("dir/document.pdf", 8, func() (io.ReadCloser, error) {
+		opened++
+		return trackedReader{strings.NewReader("contents"), &closed}, nil
+	})
+
+	s, err := NewStream(Upload{File: file, Purpose: "other"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opened != 0 {
+		t.Errorf("expected measuring not to open the reader, opened %d times", opened)
+	}
+	first := readStream(t, s)
+	if !strings.Contains(first, `filename="document.pdf"`) {
+		t.Errorf("expected the filename in the body, got\n%s", first)
+	}
+	if s.ContentLength() != int64(len(first)) {
+		t.Errorf("expected a content length of %d, got %d", len(first), s.ContentLength())
+	}
+	if second := readStream(t, s); second != first {
+		t.Errorf("expected a reopened stream to match, got\n%s", second)
+	}
+	if opened != 2 || closed != 2 {
+		t.Errorf("expected the reader to be opened and closed twice, got %d and %d", opened, closed)
+	}
+}
@@ -0,0 +1,268 @@
+// Package circuitbreaker implements a circuit breaker that is applied to
+// requests as a middleware.
+package circuitbreaker
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config configures a [Breaker].
+type Config struct {
+	// The share of failed requests within Window that opens the circuit.
+	// Defaults to 0.5.
+	FailureRatio float64
+	// The number of requests within Window below which the circuit stays
+	// closed, whatever the share of failures. Defaults to 10.
+	MinRequests int
+	// The period over which failures are counted. Defaults to 30 seconds.
+	Window time.Duration
+	// How long the circuit stays open before letting probe requests through.
+	// Defaults to 30 seconds.
+	OpenTimeout time.Duration
+	// The number of probe requests that have to succeed, while the circuit is
+	// half-open, to close it again. Defaults to 1.
+	HalfOpenProbes int
+	// Gives each of these path prefixes, such as "/card_payments", its own
+	// circuit. Other paths share the circuit of their host.
+	PathPrefixes []string
+}
+
+// State is the state of a circuit.
+type State int
+
+const (
+	// Requests are let through and their failures are counted.
+	StateClosed State = iota
+	// Requests fail fast with an [*OpenError].
+	StateOpen
+	// A limited number of probe requests are let through to find out whether
+	// the API has recovered.
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
+// ErrOpen matches every [*OpenError] with errors.Is.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// OpenError is returned, instead of sending the request, while the circuit for
+// the request's host and path prefix is open.
+type OpenError struct {
+	Host       string
+	PathPrefix string
+	// When the circuit will let probe requests through again.
+	RetryAt time.Time
+}
+
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("circuit breaker is open for %s%s until %s", e.Host, e.PathPrefix, e.RetryAt.Format(time.RFC3339))
+}
+
+func (e *OpenError) Is(target error) bool {
+	return target == ErrOpen
+}
+
+// Retryable reports false, since retrying would defeat failing fast.
+func (e *OpenError) Retryable() bool {
+	return false
+}
+
+// Breaker fails requests fast once too many of the recent requests to the same
+// host (and path prefix) failed with a connection error or a 5xx status code.
+type Breaker struct {
+	cfg      Config
+	mu       sync.Mutex
+	circuits map[circuitKey]*circuit
+}
+
+type circuitKey struct {
+	host   string
+	prefix string
+}
+
+// New returns a breaker for the given configuration.
+func New(cfg Config) *Breaker {
+	if cfg.FailureRatio <= 0 {
+		cfg.FailureRatio = 0.5
+	}
+	if cfg.MinRequests <= 0 {
+		cfg.MinRequests = 10
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = 30 * time.Second
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+	if cfg.HalfOpenProbes <= 0 {
+		cfg.HalfOpenProbes = 1
+	}
+	return &Breaker{cfg: cfg, circuits: map[circuitKey]*circuit{}}
+}
+
+// State returns the state of the circuit for the given host and path.
+func (b *Breaker) State(host string, path string) State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c := b.circuit(b.key(host, path))
+	c.advance(time.Now(), b.cfg)
+	return c.state
+}
+
+// Middleware passes the request on while its circuit is closed, and records the
+// outcome. It matches the signature of option.Middleware.
+func (b *Breaker) Middleware(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	key := b.key(req.URL.Host, req.URL.Path)
+
+	b.mu.Lock()
+	c := b.circuit(key)
+	probe, err := c.allow(time.Now(), b.cfg, key)
+	b.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := next(req)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// A request abandoned by the caller says nothing about the API's health.
+	if res == nil && req.Context().Err() != nil {
+		if probe && c.state == StateHalfOpen {
+			c.probes--
+		}
+		return res, err
+	}
+	failed := res == nil || res.StatusCode >= http.StatusInternalServerError
+	c.record(time.Now(), b.cfg, probe, failed)
+	return res, err
+}
+
+func (b *Breaker) key(host string, path string) circuitKey {
+	for _, prefix := range b.cfg.PathPrefixes {
+		if path == prefix || strings.HasPrefix(path, strings.TrimSuffix(prefix, "/")+"/") {
+			return circuitKey{host: host, prefix: prefix}
+		}
+	}
+	return circuitKey{host: host}
+}
+
+// circuit returns the circuit for the key. b.mu must be held.
+func (b *Breaker) circuit(key circuitKey) *circuit {
+	c, ok := b.circuits[key]
+	if !ok {
+		c = &circuit{}
+		b.circuits[key] = c
+	}
+	return c
+}
+
+// windowBuckets is the number of buckets the failure window is split into, so
+// that old outcomes expire gradually.
+const windowBuckets = 10
+
+type circuit struct {
+	state     State
+	openedAt  time.Time
+	probes    int
+	successes int
+	buckets   [windowBuckets]outcomes
+}
+
+type outcomes struct {
+	start    time.Time
+	total    int
+	failures int
+}
+
+// advance moves an open circuit to half-open once its timeout has passed.
+func (c *circuit) advance(now time.Time, cfg Config) {
+	if c.state == StateOpen && now.Sub(c.openedAt) >= cfg.OpenTimeout {
+		c.state = StateHalfOpen
+		c.probes = 0
+		c.successes = 0
+	}
+}
+
+// allow reports whether a request may be sent, and whether it is a probe.
+func (c *circuit) allow(now time.Time, cfg Config, key circuitKey) (probe bool, err error) {
+	c.advance(now, cfg)
+	switch c.state {
+	case StateOpen:
+		return false, &OpenError{Host: key.host, PathPrefix: key.prefix, RetryAt: c.openedAt.Add(cfg.OpenTimeout)}
+	case StateHalfOpen:
+		if c.probes >= cfg.HalfOpenProbes {
+			return false, &OpenError{Host: key.host, PathPrefix: key.prefix, RetryAt: now}
+		}
+		c.probes++
+		return true, nil
+	}
+	return false, nil
+}
+
+func (c *circuit) record(now time.Time, cfg Config, probe bool, failed bool) {
+	if probe {
+		if c.state != StateHalfOpen {
+			return
+		}
+		if failed {
+			c.open(now)
+			return
+		}
+		c.successes++
+		if c.successes >= cfg.HalfOpenProbes {
+			c.state = StateClosed
+			c.buckets = [windowBuckets]outcomes{}
+		}
+		return
+	}
+	if c.state != StateClosed {
+		return
+	}
+
+	width := cfg.Window / windowBuckets
+	if width <= 0 {
+		width = 1
+	}
+	start := now.Truncate(width)
+	bucket := &c.buckets[(start.UnixNano()/int64(width))%windowBuckets]
+	if !bucket.start.Equal(start) {
+		*bucket = outcomes{start: start}
+	}
+	bucket.total++
+	if failed {
+		bucket.failures++
+	}
+
+	total, failures := 0, 0
+	for _, b := range c.buckets {
+		if now.Sub(b.start) < cfg.Window {
+			total += b.total
+			failures += b.failures
+		}
+	}
+	if total >= cfg.MinRequests && float64(failures) >= cfg.FailureRatio*float64(total) {
+		c.open(now)
+	}
+}
+
+func (c *circuit) open(now time.Time) {
+	c.state = StateOpen
+	c.openedAt = now
+}
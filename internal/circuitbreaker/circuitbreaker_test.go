@@ -0,0 +1,80 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func send(b *Breaker, path string, status int) error {
+	req, _ := http.NewRequest(http.MethodGet, "https://api.increase.com"+path, nil)
+	_, err := b.Middleware(req, func(req *http.Request) (*http.Response, error) {
+		if status == 0 {
+			return nil, errors.New("connection refused")
+		}
+		return &http.Response{StatusCode: status, Request: req}, nil
+	})
+	return err
+}
+
+func TestBreakerOpensOnFailures(t *testing.T) {
+	b := New(Config{MinRequests: 4, FailureRatio: 0.5, OpenTimeout: time.Hour})
+	send(b, "/accounts", 200)
+	send(b, "/accounts", 200)
+	send(b, "/accounts", 503)
+	if state := b.State("api.increase.com", "/accounts"); state != StateClosed {
+		t.Fatalf("expected the circuit to stay closed below MinRequests, got %s", state)
+	}
+	send(b, "/accounts", 0)
+	if state := b.State("api.increase.com", "/accounts"); state != StateOpen {
+		t.Fatalf("expected the circuit to open, got %s", state)
+	}
+
+	err := send(b, "/accounts", 200)
+	var open *OpenError
+	if !errors.As(err, &open) || !errors.Is(err, ErrOpen) || open.Host != "api.increase.com" {
+		t.Fatalf("expected an *OpenError, got %v", err)
+	}
+}
+
+func TestBreakerIgnoresClientErrors(t *testing.T) {
+	b := New(Config{MinRequests: 2})
+	for i := 0; i < 10; i++ {
+		send(b, "/accounts", 404)
+	}
+	if state := b.State("api.increase.com", "/accounts"); state != StateClosed {
+		t.Fatalf("expected 4xx responses not to count as failures, got %s", state)
+	}
+}
+
+func TestBreakerHalfOpens(t *testing.T) {
+	b := New(Config{MinRequests: 1, OpenTimeout: 10 * time.Millisecond, HalfOpenProbes: 1})
+	send(b, "/accounts", 500)
+	time.Sleep(15 * time.Millisecond)
+	if state := b.State("api.increase.com", "/accounts"); state != StateHalfOpen {
+		t.Fatalf("expected the circuit to be half-open, got %s", state)
+	}
+	send(b, "/accounts", 500)
+	if state := b.State("api.increase.com", "/accounts"); state != StateOpen {
+		t.Fatalf("expected a failed probe to reopen the circuit, got %s", state)
+	}
+	time.Sleep(15 * time.Millisecond)
+	if err := send(b, "/accounts", 200); err != nil {
+		t.Fatal(err)
+	}
+	if state := b.State("api.increase.com", "/accounts"); state != StateClosed {
+		t.Fatalf("expected a successful probe to close the circuit, got %s", state)
+	}
+}
+
+func TestBreakerPathPrefixes(t *testing.T) {
+	b := New(Config{MinRequests: 1, OpenTimeout: time.Hour, PathPrefixes: []string{"/card_payments"}})
+	send(b, "/card_payments/card_payment_123", 500)
+	if err := send(b, "/card_payments", 200); !errors.Is(err, ErrOpen) {
+		t.Fatalf("expected the /card_payments circuit to be open, got %v", err)
+	}
+	if err := send(b, "/real_time_decisions/real_time_decision_123", 200); err != nil {
+		t.Fatalf("expected other paths not to be affected, got %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+// Package config loads client settings from the environment and from profiles
+// of a config file.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Settings are the client settings of a profile, after environment variables
+// have been applied.
+type Settings struct {
+	// The profile the settings were read from, if any.
+	Profile     string
+	APIKey      string
+	Environment string
+	BaseURL     string
+	// Nil if not set.
+	MaxRetries *int
+	// Zero if not set.
+	Timeout time.Duration
+}
+
+// Environments maps the names accepted for the environment to their base URLs.
+var Environments = map[string]string{
+	"production": "https://api.increase.com/",
+	"sandbox":    "https://sandbox.increase.com/",
+}
+
+// DefaultPath returns the path of the config file: INCREASE_CONFIG_FILE if set,
+// or increase/config.toml in the user's config directory, such as
+// ~/.config/increase/config.toml.
+func DefaultPath() string {
+	if path, ok := os.LookupEnv("INCREASE_CONFIG_FILE"); ok {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "increase", "config.toml")
+}
+
+// Load reads the settings of the profile from the config file at path, and
+// then overrides them with the INCREASE_API_KEY, INCREASE_ENVIRONMENT,
+// INCREASE_BASE_URL, INCREASE_MAX_RETRIES and INCREASE_TIMEOUT environment
+// variables. INCREASE_ENVIRONMENT also overrides a base URL from the file.
+//
+// If profile is empty, INCREASE_PROFILE is used, or else "default". A missing
+// config file is not an error, unless a profile was asked for explicitly.
+func Load(path string, profile string) (Settings, error) {
+	explicit := profile != ""
+	if !explicit {
+		profile = os.Getenv("INCREASE_PROFILE")
+		explicit = profile != ""
+	}
+	if profile == "" {
+		profile = "default"
+	}
+
+	values := map[string]string{}
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		tables, err := parseTOML(data)
+		if err != nil {
+			return Settings{}, fmt.Errorf("error parsing %s: %w", path, err)
+		}
+		table, ok := tables[profile]
+		if !ok && explicit {
+			return Settings{}, fmt.Errorf("profile %q not found in %s", profile, path)
+		}
+		// Keys before the first table apply to every profile.
+		for key, value := range tables[""] {
+			values[key] = value
+		}
+		for key, value := range table {
+			values[key] = value
+		}
+	case errors.Is(err, fs.ErrNotExist) && !explicit:
+	default:
+		return Settings{}, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	for key, env := range map[string]string{
+		"api_key":     "INCREASE_API_KEY",
+		"environment": "INCREASE_ENVIRONMENT",
+		"base_url":    "INCREASE_BASE_URL",
+		"max_retries": "INCREASE_MAX_RETRIES",
+		"timeout":     "INCREASE_TIMEOUT",
+	} {
+		if value, ok := os.LookupEnv(env); ok {
+			values[key] = value
+		}
+	}
+	// An environment from the environment variables takes precedence over a
+	// base URL from the file.
+	if _, ok := os.LookupEnv("INCREASE_ENVIRONMENT"); ok {
+		if _, ok := os.LookupEnv("INCREASE_BASE_URL"); !ok {
+			delete(values, "base_url")
+		}
+	}
+
+	return parseSettings(profile, values)
+}
+
+func parseSettings(profile string, values map[string]string) (Settings, error) {
+	settings := Settings{
+		Profile:     profile,
+		APIKey:      values["api_key"],
+		Environment: strings.ToLower(values["environment"]),
+		BaseURL:     values["base_url"],
+	}
+	if _, ok := Environments[settings.Environment]; settings.Environment != "" && !ok {
+		return Settings{}, fmt.Errorf("unknown environment %q, expected production or sandbox", values["environment"])
+	}
+	if settings.BaseURL != "" {
+		if u, err := url.Parse(settings.BaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+			return Settings{}, fmt.Errorf("invalid base URL %q", settings.BaseURL)
+		}
+	}
+	if value, ok := values["max_retries"]; ok {
+		retries, err := strconv.Atoi(value)
+		if err != nil || retries < 0 {
+			return Settings{}, fmt.Errorf("invalid max retries %q", value)
+		}
+		settings.MaxRetries = &retries
+	}
+	if value, ok := values["timeout"]; ok {
+		timeout, err := parseDuration(value)
+		if err != nil {
+			return Settings{}, err
+		}
+		settings.Timeout = timeout
+	}
+	return settings, nil
+}
+
+// parseDuration accepts a Go duration, such as "30s", or a number of seconds.
+func parseDuration(value string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid timeout %q, expected a duration such as \"30s\"", value)
+}
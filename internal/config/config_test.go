@@ -0,0 +1,133 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testConfig = `
+# Applies to every profile.
+max_retries = 3
+
+[default]
+api_key = "default_key" # trailing comment
+environment = "sandbox"
+
+["program b"]
+api_key = 'key#with#hashes'
+base_url = "https://increase-proxy.internal/"
+timeout = 2.5
+`
+
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func clearEnv(t *testing.T) {
+	for _, env := range []string{"INCREASE_API_KEY", "INCREASE_ENVIRONMENT", "INCREASE_BASE_URL", "INCREASE_MAX_RETRIES", "INCREASE_TIMEOUT", "INCREASE_PROFILE"} {
+		t.Setenv(env, "")
+		os.Unsetenv(env)
+	}
+}
+
+func TestLoadProfiles(t *testing.T) {
+	clearEnv(t)
+	path := writeConfig(t, testConfig)
+
+	settings, err := Load(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.Profile != "default" || settings.APIKey != "default_key" || settings.Environment != "sandbox" || *settings.MaxRetries != 3 {
+		t.Fatalf("unexpected default profile: %+v", settings)
+	}
+
+	settings, err = Load(path, "program b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.APIKey != "key#with#hashes" || settings.BaseURL != "https://increase-proxy.internal/" || settings.Timeout != 2500*time.Millisecond || *settings.MaxRetries != 3 {
+		t.Fatalf("unexpected program b profile: %+v", settings)
+	}
+
+	if _, err := Load(path, "missing"); err == nil || !strings.Contains(err.Error(), `profile "missing" not found`) {
+		t.Fatalf("expected an error for a missing profile, got %v", err)
+	}
+}
+
+func TestLoadEnvironmentOverrides(t *testing.T) {
+	clearEnv(t)
+	path := writeConfig(t, testConfig)
+	t.Setenv("INCREASE_PROFILE", "program b")
+	t.Setenv("INCREASE_API_KEY", "env_key")
+	t.Setenv("INCREASE_MAX_RETRIES", "0")
+	t.Setenv("INCREASE_TIMEOUT", "1m")
+
+	settings, err := Load(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.Profile != "program b" || settings.APIKey != "env_key" || *settings.MaxRetries != 0 || settings.Timeout != time.Minute {
+		t.Fatalf("expected the environment to override the file, got %+v", settings)
+	}
+}
+
+func TestLoadEnvironmentOverridesBaseURL(t *testing.T) {
+	clearEnv(t)
+	path := writeConfig(t, testConfig)
+	t.Setenv("INCREASE_ENVIRONMENT", "sandbox")
+
+	settings, err := Load(path, "program b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.Environment != "sandbox" || settings.BaseURL != "" {
+		t.Fatalf("expected the environment to replace the base URL of the file, got %+v", settings)
+	}
+
+	t.Setenv("INCREASE_BASE_URL", "https://increase-mock.internal/")
+	settings, err = Load(path, "program b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.BaseURL != "https://increase-mock.internal/" {
+		t.Fatalf("expected the base URL of the environment to be kept, got %+v", settings)
+	}
+}
+
+func TestLoadWithoutFile(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("INCREASE_ENVIRONMENT", "Production")
+	settings, err := Load(filepath.Join(t.TempDir(), "missing.toml"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.Environment != "production" || settings.MaxRetries != nil {
+		t.Fatalf("unexpected settings: %+v", settings)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := map[string]string{
+		"environment": "[default]\nenvironment = \"staging\"",
+		"retries":     "[default]\nmax_retries = -1",
+		"timeout":     "[default]\ntimeout = \"soon\"",
+		"base URL":    "[default]\nbase_url = \"increase.com\"",
+		"syntax":      "[default]\napi_key",
+		"value":       "[default]\napi_key = [\"a\"]",
+	}
+	for name, contents := range tests {
+		clearEnv(t)
+		if _, err := Load(writeConfig(t, contents), ""); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseTOML parses the subset of TOML that config files use: tables, and keys
+// with string, integer, float or boolean values. It returns the values of each
+// table by table name, with the keys that precede any table under "".
+func parseTOML(data []byte) (map[string]map[string]string, error) {
+	tables := map[string]map[string]string{"": {}}
+	table := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(stripComment(scanner.Text()))
+		if text == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") {
+			if !strings.HasSuffix(text, "]") || strings.HasPrefix(text, "[[") {
+				return nil, fmt.Errorf("line %d: invalid table header %q", line, text)
+			}
+			table = unquoteKey(strings.TrimSpace(text[1 : len(text)-1]))
+			if _, ok := tables[table]; !ok {
+				tables[table] = map[string]string{}
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value, got %q", line, text)
+		}
+		key = unquoteKey(strings.TrimSpace(key))
+		value, err := parseValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		tables[table][key] = value
+	}
+	return tables, scanner.Err()
+}
+
+// stripComment removes a trailing comment, outside of any quoted string.
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++ // Skip the escaped character.
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func unquoteKey(key string) string {
+	if value, err := parseValue(key); err == nil && len(key) >= 2 && (key[0] == '"' || key[0] == '\'') {
+		return value
+	}
+	return key
+}
+
+func parseValue(value string) (string, error) {
+	switch {
+	case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid string %s", value)
+		}
+		return unquoted, nil
+	case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+		return value[1 : len(value)-1], nil
+	case value == "true" || value == "false":
+		return value, nil
+	}
+	if _, err := strconv.ParseFloat(strings.ReplaceAll(value, "_", ""), 64); err == nil {
+		return strings.ReplaceAll(value, "_", ""), nil
+	}
+	return "", fmt.Errorf("unsupported value %q", value)
+}
@@ -0,0 +1,251 @@
+// Package hedge implements request hedging for safe methods, applied to
+// requests as a middleware.
+package hedge
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config configures a [Hedger].
+type Config struct {
+	// The percentile of the recent latencies of an endpoint after which a second
+	// request is sent, between 0 and 1. Defaults to 0.95.
+	Percentile float64
+	// The delay used until MinSamples latencies were observed for an endpoint.
+	// Defaults to 200 milliseconds.
+	InitialDelay time.Duration
+	// Bounds of the delay. MinDelay keeps every request from being sent twice
+	// when the API is fast; MaxDelay, if set, sends the second request by then
+	// whatever the latencies. MinDelay defaults to 10 milliseconds.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+	// The number of recent latencies kept for each endpoint. Defaults to 200.
+	Window int
+	// The number of latencies needed before the percentile is used. Defaults to
+	// 20.
+	MinSamples int
+}
+
+// Hedger sends a second request when a GET, HEAD or OPTIONS request takes
+// longer than usual for its endpoint, and returns whichever response arrives
+// first. Other methods are passed through.
+type Hedger struct {
+	cfg       Config
+	mu        sync.Mutex
+	latencies map[string]*window
+}
+
+// New returns a hedger for the given configuration.
+func New(cfg Config) *Hedger {
+	if cfg.Percentile <= 0 || cfg.Percentile > 1 {
+		cfg.Percentile = 0.95
+	}
+	if cfg.InitialDelay <= 0 {
+		cfg.InitialDelay = 200 * time.Millisecond
+	}
+	if cfg.MinDelay <= 0 {
+		cfg.MinDelay = 10 * time.Millisecond
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = 200
+	}
+	if cfg.MinSamples <= 0 {
+		cfg.MinSamples = 20
+	}
+	return &Hedger{cfg: cfg, latencies: map[string]*window{}}
+}
+
+// Delay returns how long a request for the given method and path waits before
+// it is hedged.
+func (h *Hedger) Delay(method string, path string) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.delay(endpoint(method, path))
+}
+
+// delay returns the delay for the endpoint. h.mu must be held.
+func (h *Hedger) delay(key string) time.Duration {
+	delay := h.cfg.InitialDelay
+	if w, ok := h.latencies[key]; ok && len(w.samples) >= h.cfg.MinSamples {
+		delay = w.percentile(h.cfg.Percentile)
+	}
+	if delay < h.cfg.MinDelay {
+		delay = h.cfg.MinDelay
+	}
+	if h.cfg.MaxDelay > 0 && delay > h.cfg.MaxDelay {
+		delay = h.cfg.MaxDelay
+	}
+	return delay
+}
+
+func (h *Hedger) observe(key string, latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	w, ok := h.latencies[key]
+	if !ok {
+		w = &window{size: h.cfg.Window}
+		h.latencies[key] = w
+	}
+	w.add(latency)
+}
+
+type result struct {
+	attempt int
+	res     *http.Response
+	err     error
+	cancel  context.CancelFunc
+	latency time.Duration
+}
+
+func (r result) succeeded() bool {
+	return r.err == nil && r.res.StatusCode < http.StatusInternalServerError
+}
+
+// Middleware sends the request and, if no response arrived after the delay for
+// its endpoint, sends it a second time. The first successful response is
+// returned and the other request is canceled. It matches the signature of
+// option.Middleware.
+func (h *Hedger) Middleware(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	if !safe(req.Method) || (req.Body != nil && req.Body != http.NoBody) {
+		return next(req)
+	}
+	key := endpoint(req.Method, req.URL.Path)
+	h.mu.Lock()
+	delay := h.delay(key)
+	h.mu.Unlock()
+
+	results := make(chan result, 2)
+	var cancels []context.CancelFunc
+	send := func() {
+		ctx, cancel := context.WithCancel(req.Context())
+		attempt := len(cancels)
+		cancels = append(cancels, cancel)
+		start := time.Now()
+		go func() {
+			res, err := next(req.Clone(ctx))
+			results <- result{attempt: attempt, res: res, err: err, cancel: cancel, latency: time.Since(start)}
+		}()
+	}
+	send()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	var failed *result
+	for received := 0; ; {
+		select {
+		case <-timer.C:
+			if failed == nil {
+				send()
+			}
+		case r := <-results:
+			received++
+			if r.succeeded() {
+				h.observe(key, r.latency)
+				if failed != nil {
+					release(*failed)
+				}
+				for attempt, cancel := range cancels {
+					if attempt != r.attempt {
+						cancel()
+					}
+				}
+				go discard(results, len(cancels)-received)
+				return keep(r)
+			}
+			if failed == nil {
+				failed = &r
+			} else {
+				release(r)
+			}
+			// Without a request in flight, the failure is left to the retries.
+			if received == len(cancels) {
+				return keep(*failed)
+			}
+		}
+	}
+}
+
+// discard waits for the requests that lost the race, which were canceled, and
+// closes their responses.
+func discard(results chan result, pending int) {
+	for i := 0; i < pending; i++ {
+		release(<-results)
+	}
+}
+
+func release(r result) {
+	r.cancel()
+	if r.res != nil {
+		r.res.Body.Close()
+	}
+}
+
+func safe(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// endpoint identifies the endpoint of a request, with the object IDs in its path
+// replaced, so that for example every card shares the latencies of
+// "GET /cards/*".
+func endpoint(method string, path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 1; i < len(segments); i++ {
+		if strings.Contains(segments[i], "_") {
+			segments[i] = "*"
+		}
+	}
+	return method + " /" + strings.Join(segments, "/")
+}
+
+// window holds the most recent latencies of an endpoint.
+type window struct {
+	size    int
+	next    int
+	samples []time.Duration
+}
+
+func (w *window) add(latency time.Duration) {
+	if len(w.samples) < w.size {
+		w.samples = append(w.samples, latency)
+		return
+	}
+	w.samples[w.next] = latency
+	w.next = (w.next + 1) % w.size
+}
+
+func (w *window) percentile(p float64) time.Duration {
+	sorted := append([]time.Duration(nil), w.samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	i := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[max(i, 0)]
+}
+
+// cancelOnClose keeps the request of the returned response alive until its
+// body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// keep returns the response of r, canceling its request once the response body
+// is closed.
+func keep(r result) (*http.Response, error) {
+	if r.res == nil {
+		r.cancel()
+		return nil, r.err
+	}
+	r.res.Body = cancelOnClose{ReadCloser: r.res.Body, cancel: r.cancel}
+	return r.res, r.err
+}
@@ -0,0 +1,113 @@
+package hedge
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newResponse(status int, body string) *http.Response {
+	return &http.Response{StatusCode: status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func newRequest(t *testing.T, method string) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(method, "https://api.increase.com/cards/card_oubs0hwk5rn6knuecxg2", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+func TestMiddlewareHedgesSlowRequest(t *testing.T) {
+	h := New(Config{InitialDelay: 10 * time.Millisecond})
+	var calls atomic.Int32
+	canceled := make(chan struct{})
+	next := func(req *http.Request) (*http.Response, error) {
+		if calls.Add(1) == 1 {
+			<-req.Context().Done()
+			close(canceled)
+			return nil, req.Context().Err()
+		}
+		return newResponse(200, "hedge"), nil
+	}
+
+	res, err := h.Middleware(newRequest(t, http.MethodGet), next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	if string(body) != "hedge" || calls.Load() != 2 {
+		t.Fatalf("expected the hedged response after 2 requests, got %q after %d", body, calls.Load())
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("expected the slow request to be canceled")
+	}
+}
+
+func TestMiddlewareDoesNotHedgeFastRequest(t *testing.T) {
+	h := New(Config{InitialDelay: time.Second})
+	var calls atomic.Int32
+	res, err := h.Middleware(newRequest(t, http.MethodGet), func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		return newResponse(200, "{}"), nil
+	})
+	if err != nil || res.StatusCode != 200 || calls.Load() != 1 {
+		t.Fatalf("expected a single request, got %d (%v)", calls.Load(), err)
+	}
+	res.Body.Close()
+}
+
+func TestMiddlewareReturnsEarlyFailure(t *testing.T) {
+	h := New(Config{InitialDelay: time.Second})
+	var calls atomic.Int32
+	_, err := h.Middleware(newRequest(t, http.MethodGet), func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		return nil, errors.New("connection reset")
+	})
+	if err == nil || calls.Load() != 1 {
+		t.Fatalf("expected the failure to be returned without hedging, got %d requests (%v)", calls.Load(), err)
+	}
+}
+
+func TestMiddlewareDoesNotHedgeUnsafeMethods(t *testing.T) {
+	h := New(Config{InitialDelay: time.Millisecond})
+	var calls atomic.Int32
+	res, err := h.Middleware(newRequest(t, http.MethodPost), func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		return newResponse(200, "{}"), nil
+	})
+	if err != nil || res.StatusCode != 200 || calls.Load() != 1 {
+		t.Fatalf("expected a POST to be sent once, got %d requests (%v)", calls.Load(), err)
+	}
+}
+
+func TestDelay(t *testing.T) {
+	h := New(Config{Percentile: 0.9, InitialDelay: 50 * time.Millisecond, MinDelay: 5 * time.Millisecond, MaxDelay: 80 * time.Millisecond, MinSamples: 10})
+	if delay := h.Delay(http.MethodGet, "/cards/card_1"); delay != 50*time.Millisecond {
+		t.Fatalf("expected the initial delay without samples, got %s", delay)
+	}
+	for i := 1; i <= 10; i++ {
+		h.observe(endpoint(http.MethodGet, "/cards/card_"+strings.Repeat("x", i)), time.Duration(i)*time.Millisecond)
+	}
+	if delay := h.Delay(http.MethodGet, "/cards/card_2"); delay != 9*time.Millisecond {
+		t.Errorf("expected the 90th percentile of the endpoint, got %s", delay)
+	}
+	if delay := h.Delay(http.MethodGet, "/accounts/account_1/balance"); delay != 50*time.Millisecond {
+		t.Errorf("expected other endpoints to use the initial delay, got %s", delay)
+	}
+	for i := 0; i < 5; i++ {
+		h.observe(endpoint(http.MethodGet, "/cards/card_1"), time.Second)
+	}
+	if delay := h.Delay(http.MethodGet, "/cards/card_1"); delay != 80*time.Millisecond {
+		t.Errorf("expected the delay to be capped, got %s", delay)
+	}
+}
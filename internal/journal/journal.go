@@ -0,0 +1,203 @@
+// Package journal provides stores for the idempotency journal of
+// [requestconfig.RequestConfig].
+package journal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/increase/increase-go/internal/requestconfig"
+)
+
+// MemoryStore keeps the journal in memory. It survives retries and replays
+// within a process, but not a restart.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]requestconfig.JournalEntry
+}
+
+// NewMemoryStore returns an empty [MemoryStore].
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: map[string]requestconfig.JournalEntry{}}
+}
+
+func (s *MemoryStore) Get(ctx context.Context, idempotencyKey string) (*requestconfig.JournalEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[idempotencyKey]
+	if !ok {
+		return nil, nil
+	}
+	return &entry, nil
+}
+
+func (s *MemoryStore) Put(ctx context.Context, entry *requestconfig.JournalEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[entry.IdempotencyKey] = *entry
+	return nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, idempotencyKey string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, idempotencyKey)
+	return nil
+}
+
+func (s *MemoryStore) Pending(ctx context.Context) ([]*requestconfig.JournalEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var pending []*requestconfig.JournalEntry
+	for _, entry := range s.entries {
+		if entry.Status == requestconfig.JournalStatusPending {
+			entry := entry
+			pending = append(pending, &entry)
+		}
+	}
+	sortByCreation(pending)
+	return pending, nil
+}
+
+// FileStore keeps the journal in a directory, with one JSON file per entry.
+// Entries are written atomically and synced to disk before a request is sent,
+// so that they survive a crash.
+type FileStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileStore returns a [FileStore] that keeps its entries in dir, creating
+// the directory if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) path(idempotencyKey string) string {
+	sum := sha256.Sum256([]byte(idempotencyKey))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (s *FileStore) Get(ctx context.Context, idempotencyKey string) (*requestconfig.JournalEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return readEntry(s.path(idempotencyKey))
+}
+
+func (s *FileStore) Put(ctx context.Context, entry *requestconfig.JournalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tmp, err := os.CreateTemp(s.dir, ".entry-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path(entry.IdempotencyKey)); err != nil {
+		return err
+	}
+	return syncDir(s.dir)
+}
+
+func (s *FileStore) Delete(ctx context.Context, idempotencyKey string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := os.Remove(s.path(idempotencyKey))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return syncDir(s.dir)
+}
+
+// syncDir syncs the directory, so that files renamed into it or removed from it
+// stay so after a power failure. Windows cannot sync directories, and updates
+// its directory entries with the file.
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (s *FileStore) Pending(ctx context.Context) ([]*requestconfig.JournalEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	var pending []*requestconfig.JournalEntry
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		entry, err := readEntry(filepath.Join(s.dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if entry != nil && entry.Status == requestconfig.JournalStatusPending {
+			pending = append(pending, entry)
+		}
+	}
+	sortByCreation(pending)
+	return pending, nil
+}
+
+func readEntry(path string) (*requestconfig.JournalEntry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	entry := &requestconfig.JournalEntry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func sortByCreation(entries []*requestconfig.JournalEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+}
@@ -0,0 +1,171 @@
+package journal
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/increase/increase-go/internal/requestconfig"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+type transfer struct {
+	ID string `json:"id"`
+}
+
+func newTransfer(t *testing.T, store requestconfig.JournalStore, transport roundTripFunc) (*transfer, error) {
+	t.Helper()
+	return newTransferWithBody(t, store, `{"amount":100}`, transport)
+}
+
+func newTransferWithBody(t *testing.T, store requestconfig.JournalStore, body string, transport roundTripFunc) (*transfer, error) {
+	t.Helper()
+	base, _ := url.Parse("https://api.increase.com/")
+	var res *transfer
+	err := requestconfig.ExecuteNewRequest(context.Background(), http.MethodPost, "ach_transfers", nil, &res, func(r *requestconfig.RequestConfig) error {
+		r.BaseURL = base
+		r.HTTPClient = &http.Client{Transport: transport}
+		r.MaxRetries = 0
+		r.Journal = store
+		r.IdempotencyKey = "payout-1"
+		r.Buffer = []byte(body)
+		return nil
+	})
+	return res, err
+}
+
+func respond(status int, body string) roundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	}
+}
+
+func testStore(t *testing.T, store requestconfig.JournalStore) {
+	if _, err := newTransfer(t, store, respond(503, `{}`)); err == nil {
+		t.Fatal("expected an error")
+	}
+	pending, err := store.Pending(context.Background())
+	if err != nil || len(pending) != 1 || string(pending[0].RequestBody) != `{"amount":100}` {
+		t.Fatalf("expected the failed request to be pending, got %v %v", pending, err)
+	}
+
+	res, err := newTransfer(t, store, respond(200, `{"id":"ach_transfer_1"}`))
+	if err != nil || res.ID != "ach_transfer_1" {
+		t.Fatalf("expected the request to succeed, got %v %v", res, err)
+	}
+
+	res, err = newTransfer(t, store, func(req *http.Request) (*http.Response, error) {
+		t.Fatal("expected the recorded response to be used")
+		return nil, nil
+	})
+	if err != nil || res.ID != "ach_transfer_1" {
+		t.Fatalf("expected the recorded response, got %v %v", res, err)
+	}
+	if pending, _ := store.Pending(context.Background()); len(pending) != 0 {
+		t.Fatalf("expected no pending entries, got %d", len(pending))
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, store)
+
+	// A new store on the same directory, as after a restart, sees the entries.
+	reopened, _ := NewFileStore(dir)
+	entry, err := reopened.Get(context.Background(), "payout-1")
+	if err != nil || entry == nil || entry.Status != requestconfig.JournalStatusCompleted {
+		t.Fatalf("expected the completed entry to be persisted, got %v %v", entry, err)
+	}
+}
+
+func TestJournalRejectsDifferentBody(t *testing.T) {
+	store := NewMemoryStore()
+	if _, err := newTransfer(t, store, respond(200, `{"id":"ach_transfer_1"}`)); err != nil {
+		t.Fatal(err)
+	}
+	res, err := newTransferWithBody(t, store, `{"amount":999}`, func(req *http.Request) (*http.Response, error) {
+		t.Fatal("expected no request to be sent")
+		return nil, nil
+	})
+	if err == nil || res != nil {
+		t.Fatalf("expected an error for a different body, got %v", res)
+	}
+}
+
+func TestJournalForgetsRejectedRequests(t *testing.T) {
+	for _, status := range []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusConflict} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			store := NewMemoryStore()
+			if _, err := newTransfer(t, store, respond(status, `{}`)); err == nil {
+				t.Fatal("expected an error")
+			}
+			if entry, _ := store.Get(context.Background(), "payout-1"); entry != nil {
+				t.Fatalf("expected the rejected request to be removed, got %+v", entry)
+			}
+
+			// Once the problem is fixed, the request is sent again.
+			sent := false
+			res, err := newTransfer(t, store, func(req *http.Request) (*http.Response, error) {
+				sent = true
+				return respond(200, `{"id":"ach_transfer_1"}`)(req)
+			})
+			if err != nil || !sent || res.ID != "ach_transfer_1" {
+				t.Fatalf("expected the request to be sent again, got %v %v", res, err)
+			}
+		})
+	}
+}
+
+func TestReplayJournal(t *testing.T) {
+	store := NewMemoryStore()
+	store.Put(context.Background(), &requestconfig.JournalEntry{
+		IdempotencyKey: "payout-2",
+		Status:         requestconfig.JournalStatusPending,
+		Method:         http.MethodPost,
+		URL:            "https://api.increase.com/ach_transfers",
+		ContentType:    "application/json",
+		RequestBody:    []byte(`{"amount":200}`),
+		CreatedAt:      time.Now(),
+	})
+
+	var sent *http.Request
+	var body []byte
+	err := requestconfig.ReplayJournal(context.Background(), store, func(r *requestconfig.RequestConfig) error {
+		r.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			sent = req
+			body, _ = io.ReadAll(req.Body)
+			return respond(200, `{"id":"ach_transfer_2"}`)(req)
+		})}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent == nil || sent.Header.Get("Idempotency-Key") != "payout-2" || string(body) != `{"amount":200}` {
+		t.Fatalf("expected the pending request to be sent again with its key, got %v %s", sent, body)
+	}
+	entry, _ := store.Get(context.Background(), "payout-2")
+	if entry.Status != requestconfig.JournalStatusCompleted || string(entry.ResponseBody) != `{"id":"ach_transfer_2"}` {
+		t.Fatalf("expected the response to be recorded, got %+v", entry)
+	}
+}
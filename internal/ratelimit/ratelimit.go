@@ -0,0 +1,232 @@
+// Package ratelimit implements a client-side token bucket rate limiter that is
+// applied to requests as a middleware.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/increase/increase-go/internal/requestconfig"
+)
+
+// Config configures a [Limiter].
+type Config struct {
+	// The number of requests per second allowed for each API key. Zero means
+	// requests are only limited by Endpoints.
+	Rate float64
+	// The number of requests that may be made at once after a quiet period.
+	// Defaults to Rate, rounded up.
+	Burst int
+	// The lowest rate the limiter backs off to after being rate limited by the
+	// API. Defaults to a tenth of the configured rate.
+	MinRate float64
+	// Additional limits for parts of the API, applied on top of Rate. The first
+	// matching limit is used.
+	Endpoints []EndpointLimit
+}
+
+// EndpointLimit limits the requests made to matching paths, such as
+// "/ach_transfers" or "/simulations/*".
+type EndpointLimit struct {
+	// A pattern matches the request path exactly, or any path below it when it
+	// ends in "/*".
+	Pattern string
+	Rate    float64
+	Burst   int
+	MinRate float64
+}
+
+func (e EndpointLimit) matches(path string) bool {
+	if strings.HasSuffix(e.Pattern, "/*") {
+		return strings.HasPrefix(path, strings.TrimSuffix(e.Pattern, "*"))
+	}
+	return path == e.Pattern
+}
+
+// Limiter throttles the requests made by every client and service that share
+// it. Each API key gets its own buckets. When the API responds with 429 Too
+// Many Requests, the rate of the buckets involved is halved, and any
+// `Retry-After` delay is respected by all waiting requests. The rate then
+// recovers gradually with every successful response.
+type Limiter struct {
+	cfg     Config
+	mu      sync.Mutex
+	buckets map[bucketKey]*bucket
+	waiting atomic.Int64
+}
+
+type bucketKey struct {
+	apiKey  string
+	pattern string
+}
+
+// New returns a limiter for the given configuration.
+func New(cfg Config) *Limiter {
+	return &Limiter{cfg: cfg, buckets: map[bucketKey]*bucket{}}
+}
+
+// QueueDepth returns the number of requests that are currently waiting for the
+// limiter.
+func (l *Limiter) QueueDepth() int {
+	return int(l.waiting.Load())
+}
+
+// Rate returns the current rate, in requests per second, for requests to the
+// given path made with the given API key. It returns 0 if such requests are not
+// limited.
+func (l *Limiter) Rate(apiKey string, path string) float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	rate := 0.0
+	for _, b := range l.bucketsFor("Bearer "+apiKey, path) {
+		if rate == 0 || b.rate < rate {
+			rate = b.rate
+		}
+	}
+	return rate
+}
+
+// Middleware waits for the limiter before passing the request on, and adapts
+// the limiter to the response. It matches the signature of option.Middleware.
+func (l *Limiter) Middleware(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	if err := l.wait(req.Context(), req.Header.Get("Authorization"), req.URL.Path); err != nil {
+		return nil, err
+	}
+	res, err := next(req)
+	if res != nil {
+		l.observe(req.Header.Get("Authorization"), req.URL.Path, res)
+	}
+	return res, err
+}
+
+func (l *Limiter) wait(ctx context.Context, authorization string, path string) error {
+	now := time.Now()
+	l.mu.Lock()
+	buckets := l.bucketsFor(authorization, path)
+	var delay time.Duration
+	for _, b := range buckets {
+		if d := b.reserve(now); d > delay {
+			delay = d
+		}
+	}
+	l.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+
+	l.waiting.Add(1)
+	defer l.waiting.Add(-1)
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		for _, b := range buckets {
+			b.release()
+		}
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+func (l *Limiter) observe(authorization string, path string, res *http.Response) {
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, b := range l.bucketsFor(authorization, path) {
+		if res.StatusCode == http.StatusTooManyRequests {
+			retryAfter, _ := requestconfig.RetryAfter(requestconfig.RetryAttempt{Response: res})
+			b.throttle(now, retryAfter)
+		} else if res.StatusCode < 400 {
+			b.recover()
+		}
+	}
+}
+
+// bucketsFor returns the buckets a request has to pass. l.mu must be held.
+func (l *Limiter) bucketsFor(authorization string, path string) []*bucket {
+	var buckets []*bucket
+	if l.cfg.Rate > 0 {
+		buckets = append(buckets, l.bucket(bucketKey{apiKey: authorization}, l.cfg.Rate, l.cfg.Burst, l.cfg.MinRate))
+	}
+	for _, endpoint := range l.cfg.Endpoints {
+		if endpoint.Rate > 0 && endpoint.matches(path) {
+			key := bucketKey{apiKey: authorization, pattern: endpoint.Pattern}
+			buckets = append(buckets, l.bucket(key, endpoint.Rate, endpoint.Burst, endpoint.MinRate))
+			break
+		}
+	}
+	return buckets
+}
+
+func (l *Limiter) bucket(key bucketKey, rate float64, burst int, minRate float64) *bucket {
+	if b, ok := l.buckets[key]; ok {
+		return b
+	}
+	if burst <= 0 {
+		burst = int(math.Ceil(rate))
+	}
+	if minRate <= 0 || minRate > rate {
+		minRate = rate / 10
+	}
+	b := &bucket{
+		maxRate: rate,
+		minRate: minRate,
+		rate:    rate,
+		burst:   float64(burst),
+		tokens:  float64(burst),
+		last:    time.Now(),
+	}
+	l.buckets[key] = b
+	return b
+}
+
+type bucket struct {
+	maxRate     float64
+	minRate     float64
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+// reserve takes a token and returns how long the caller has to wait before it
+// may be used.
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+
+	var delay time.Duration
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	if paused := b.pausedUntil.Sub(now); paused > delay {
+		delay = paused
+	}
+	return delay
+}
+
+// release returns a token that was reserved but not used.
+func (b *bucket) release() {
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+func (b *bucket) throttle(now time.Time, retryAfter time.Duration) {
+	b.rate = math.Max(b.minRate, b.rate/2)
+	if until := now.Add(retryAfter); until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+}
+
+func (b *bucket) recover() {
+	b.rate = math.Min(b.maxRate, b.rate+b.maxRate/20)
+}
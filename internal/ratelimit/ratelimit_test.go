@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newRequest(ctx context.Context, path string) *http.Request {
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.increase.com"+path, nil)
+	req.Header.Set("Authorization", "Bearer key")
+	return req
+}
+
+func respond(status int, header http.Header) func(*http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{StatusCode: status, Header: header, Request: req}, nil
+	}
+}
+
+func TestLimiterThrottles(t *testing.T) {
+	limiter := New(Config{Rate: 50, Burst: 1})
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if _, err := limiter.Middleware(newRequest(context.Background(), "/accounts"), respond(200, nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The first request uses the burst, the other five wait 20ms each.
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("expected requests to be throttled, took %s", elapsed)
+	}
+}
+
+func TestLimiterEndpointBuckets(t *testing.T) {
+	limiter := New(Config{Endpoints: []EndpointLimit{{Pattern: "/simulations/*", Rate: 1, Burst: 1}}})
+	limiter.Middleware(newRequest(context.Background(), "/simulations/card_refunds"), respond(200, nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.Middleware(newRequest(ctx, "/simulations/ach_transfers/inbound"), respond(200, nil)); err != context.DeadlineExceeded {
+		t.Fatalf("expected the second simulation request to wait, got %v", err)
+	}
+	if _, err := limiter.Middleware(newRequest(ctx, "/accounts"), respond(200, nil)); err != nil {
+		t.Fatalf("expected other endpoints not to be limited, got %v", err)
+	}
+}
+
+func TestLimiterAdaptsToTooManyRequests(t *testing.T) {
+	limiter := New(Config{Rate: 100})
+	limiter.Middleware(newRequest(context.Background(), "/accounts"), respond(http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}}))
+	if rate := limiter.Rate("key", "/accounts"); rate != 50 {
+		t.Fatalf("expected the rate to be halved, got %v", rate)
+	}
+	limiter.Middleware(newRequest(context.Background(), "/accounts"), respond(200, nil))
+	if rate := limiter.Rate("key", "/accounts"); rate != 55 {
+		t.Fatalf("expected the rate to recover, got %v", rate)
+	}
+}
+
+func TestLimiterQueueDepth(t *testing.T) {
+	limiter := New(Config{Rate: 1, Burst: 1})
+	limiter.Middleware(newRequest(context.Background(), "/accounts"), respond(200, nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			limiter.Middleware(newRequest(ctx, "/accounts"), respond(200, nil))
+		}()
+	}
+	deadline := time.Now().Add(time.Second)
+	for limiter.QueueDepth() != 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if depth := limiter.QueueDepth(); depth != 3 {
+		t.Fatalf("expected 3 waiting requests, got %d", depth)
+	}
+	cancel()
+	wg.Wait()
+	if depth := limiter.QueueDepth(); depth != 0 {
+		t.Fatalf("expected no waiting requests, got %d", depth)
+	}
+}
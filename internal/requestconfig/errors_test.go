@@ -0,0 +1,102 @@
+package requestconfig
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/increase/increase-go/internal/apierror"
+)
+
+func TestExecuteReturnsDecodeError(t *testing.T) {
+	cfg := newTestConfig(t, func(req *http.Request) (*http.Response, error) {
+		return newResponse(http.StatusOK, http.Header{"Content-Type": {"application/json"}}, `{"id": 1`), nil
+	})
+	var dst map[string]interface{}
+	cfg.ResponseBodyInto = &dst
+
+	err := cfg.Execute()
+	var decodeErr *apierror.DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected a *DecodeError, got %#v", err)
+	}
+	if string(decodeErr.Body) != `{"id": 1` || decodeErr.Type != reflect.TypeOf(&dst) {
+		t.Fatalf("expected the raw body and target type, got %q and %v", decodeErr.Body, decodeErr.Type)
+	}
+	if apierror.IsRetryable(err) {
+		t.Fatal("expected a decode error not to be retryable")
+	}
+}
+
+func TestExecuteReturnsConnectionError(t *testing.T) {
+	cfg := newTestConfig(t, func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	}, func(r *RequestConfig) error {
+		r.MaxRetries = 0
+		r.RequestTimeout = time.Millisecond
+		return nil
+	})
+
+	err := cfg.Execute()
+	var connErr *apierror.ConnectionError
+	if !errors.As(err, &connErr) || connErr.Request == nil {
+		t.Fatalf("expected a *ConnectionError, got %#v", err)
+	}
+	if !errors.Is(err, apierror.ErrTimeout) || !errors.Is(err, apierror.ErrConnection) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the error to match ErrTimeout, ErrConnection and context.DeadlineExceeded, got %v", err)
+	}
+	if !apierror.IsRetryable(err) {
+		t.Fatal("expected a timeout to be retryable")
+	}
+}
+
+type rejectedError struct{}
+
+func (rejectedError) Error() string   { return "rejected" }
+func (rejectedError) Retryable() bool { return false }
+
+func TestExecutePassesThroughMiddlewareErrors(t *testing.T) {
+	cfg := newTestConfig(t, func(req *http.Request) (*http.Response, error) {
+		t.Fatal("expected no request to be sent")
+		return nil, nil
+	}, func(r *RequestConfig) error {
+		r.Middlewares = append(r.Middlewares, func(req *http.Request, next middlewareNext) (*http.Response, error) {
+			return nil, rejectedError{}
+		})
+		return nil
+	})
+
+	err := cfg.Execute()
+	if err != (rejectedError{}) {
+		t.Fatalf("expected the error of the middleware, got %#v", err)
+	}
+	if errors.Is(err, apierror.ErrConnection) {
+		t.Fatal("expected a middleware error not to be a connection error")
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := map[string]struct {
+		err       error
+		retryable bool
+	}{
+		"conflict":     {&apierror.Error{StatusCode: 409}, true},
+		"server error": {&apierror.Error{StatusCode: 503}, true},
+		"bad request":  {&apierror.Error{StatusCode: 400}, false},
+		"should retry": {&apierror.Error{StatusCode: 400, Response: newResponse(400, http.Header{"X-Should-Retry": {"true"}}, "")}, true},
+		"canceled":     {&apierror.ConnectionError{Err: context.Canceled}, false},
+		"connection":   {&apierror.ConnectionError{Err: errors.New("connection reset by peer")}, true},
+		"permanent":    {&apierror.ConnectionError{Err: permanentError{}}, false},
+		"encode":       {&apierror.EncodeError{Err: errors.New("invalid")}, false},
+		"other":        {errors.New("other"), false},
+	}
+	for name, test := range tests {
+		if retryable := apierror.IsRetryable(test.err); retryable != test.retryable {
+			t.Errorf("%s: expected IsRetryable to be %v, got %v", name, test.retryable, retryable)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package requestconfig
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// JournalStatus is the state of a [JournalEntry].
+type JournalStatus string
+
+const (
+	// The request was recorded, but its outcome is not known yet, for example
+	// because the process crashed or the API could not be reached.
+	JournalStatusPending JournalStatus = "pending"
+	// The API accepted the request, and its response is recorded in the entry.
+	JournalStatusCompleted JournalStatus = "completed"
+)
+
+// JournalEntry records a mutating request that was sent with a caller-supplied
+// idempotency key, and its response once the API accepted it.
+type JournalEntry struct {
+	IdempotencyKey string        `json:"idempotency_key"`
+	Status         JournalStatus `json:"status"`
+	Method         string        `json:"method"`
+	URL            string        `json:"url"`
+	ContentType    string        `json:"content_type,omitempty"`
+	RequestBody    []byte        `json:"request_body,omitempty"`
+	// The status code, content type and body of the response, once completed.
+	StatusCode          int       `json:"status_code,omitempty"`
+	ResponseContentType string    `json:"response_content_type,omitempty"`
+	ResponseBody        []byte    `json:"response_body,omitempty"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// JournalStore persists [JournalEntry] values by idempotency key. It must be
+// safe for concurrent use.
+type JournalStore interface {
+	// Get returns the entry for the key, or nil if there is none.
+	Get(ctx context.Context, idempotencyKey string) (*JournalEntry, error)
+	// Put creates or replaces the entry for entry.IdempotencyKey.
+	Put(ctx context.Context, entry *JournalEntry) error
+	// Pending returns all entries whose status is [JournalStatusPending].
+	Pending(ctx context.Context) ([]*JournalEntry, error)
+	// Delete removes the entry for the key, if there is one.
+	Delete(ctx context.Context, idempotencyKey string) error
+}
+
+func (cfg *RequestConfig) journaled() bool {
+	// A streamed body is not kept around, so it could not be replayed.
+	return cfg.Journal != nil && cfg.IdempotencyKey != "" && IsMutating(cfg.Request.Method) && cfg.Stream == nil
+}
+
+// beginJournalEntry returns the recorded response if the request was completed
+// before. Otherwise, it records the request as pending and returns nil.
+func (cfg *RequestConfig) beginJournalEntry() (*http.Response, error) {
+	ctx := cfg.Request.Context()
+	entry, err := cfg.Journal.Get(ctx, cfg.IdempotencyKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reading idempotency journal: %w", err)
+	}
+	if entry != nil {
+		if entry.Method != cfg.Request.Method || entry.URL != cfg.Request.URL.String() {
+			return nil, fmt.Errorf("idempotency key %q was already used for %s %s", cfg.IdempotencyKey, entry.Method, entry.URL)
+		}
+		if !bytes.Equal(entry.RequestBody, cfg.Buffer) {
+			return nil, fmt.Errorf("idempotency key %q was already used for %s %s with a different body", cfg.IdempotencyKey, entry.Method, entry.URL)
+		}
+		if entry.Status == JournalStatusCompleted {
+			return &http.Response{
+				Status:     fmt.Sprintf("%d %s", entry.StatusCode, http.StatusText(entry.StatusCode)),
+				StatusCode: entry.StatusCode,
+				Header: http.Header{
+					"Content-Type":        {entry.ResponseContentType},
+					"Idempotent-Replayed": {"true"},
+				},
+				Body:          io.NopCloser(bytes.NewReader(entry.ResponseBody)),
+				ContentLength: int64(len(entry.ResponseBody)),
+				Request:       cfg.Request,
+			}, nil
+		}
+		// The request may or may not have reached the API; sending it again with
+		// the same key is safe.
+		return nil, nil
+	}
+
+	now := time.Now()
+	err = cfg.Journal.Put(ctx, &JournalEntry{
+		IdempotencyKey: cfg.IdempotencyKey,
+		Status:         JournalStatusPending,
+		Method:         cfg.Request.Method,
+		URL:            cfg.Request.URL.String(),
+		ContentType:    cfg.Request.Header.Get("Content-Type"),
+		RequestBody:    cfg.Buffer,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error writing idempotency journal: %w", err)
+	}
+	return nil, nil
+}
+
+// completeJournalEntry records the response of an accepted request. Requests
+// the API rejected, for example because of invalid credentials or a conflict,
+// are removed from the journal, so that they are sent again when they are made
+// again. Connection errors and responses that may be retried leave the entry
+// pending.
+func (cfg *RequestConfig) completeJournalEntry(res *http.Response, err error) error {
+	if err != nil || res == nil {
+		return nil
+	}
+	switch {
+	case res.StatusCode == http.StatusRequestTimeout,
+		res.StatusCode == http.StatusTooManyRequests,
+		res.StatusCode >= http.StatusInternalServerError:
+		return nil
+	case res.StatusCode < 200 || res.StatusCode >= 300:
+		if err := cfg.Journal.Delete(cfg.Request.Context(), cfg.IdempotencyKey); err != nil {
+			return fmt.Errorf("error writing idempotency journal: %w", err)
+		}
+		return nil
+	}
+
+	contents, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(contents))
+	if err != nil {
+		return fmt.Errorf("error reading response body: %w", err)
+	}
+
+	ctx := cfg.Request.Context()
+	entry, err := cfg.Journal.Get(ctx, cfg.IdempotencyKey)
+	if err != nil {
+		return fmt.Errorf("error reading idempotency journal: %w", err)
+	}
+	if entry == nil {
+		entry = &JournalEntry{
+			IdempotencyKey: cfg.IdempotencyKey,
+			Method:         cfg.Request.Method,
+			URL:            cfg.Request.URL.String(),
+			ContentType:    cfg.Request.Header.Get("Content-Type"),
+			RequestBody:    cfg.Buffer,
+			CreatedAt:      time.Now(),
+		}
+	}
+	entry.Status = JournalStatusCompleted
+	entry.StatusCode = res.StatusCode
+	entry.ResponseContentType = res.Header.Get("Content-Type")
+	entry.ResponseBody = contents
+	entry.UpdatedAt = time.Now()
+	if err := cfg.Journal.Put(ctx, entry); err != nil {
+		return fmt.Errorf("error writing idempotency journal: %w", err)
+	}
+	return nil
+}
+
+// ReplayJournal sends every pending entry of the store again, with its original
+// idempotency key, and records the responses. The given options supply the
+// settings, such as the API key, that are not recorded in the journal.
+func ReplayJournal(ctx context.Context, store JournalStore, opts ...func(*RequestConfig) error) error {
+	entries, err := store.Pending(ctx)
+	if err != nil {
+		return fmt.Errorf("error reading idempotency journal: %w", err)
+	}
+	for _, entry := range entries {
+		cfg, err := NewRequestConfig(ctx, entry.Method, entry.URL, nil, nil, opts...)
+		if err != nil {
+			return err
+		}
+		cfg.Journal = store
+		cfg.IdempotencyKey = entry.IdempotencyKey
+		cfg.Request.Header.Set("Idempotency-Key", entry.IdempotencyKey)
+		cfg.Buffer = entry.RequestBody
+		if entry.ContentType != "" {
+			cfg.Request.Header.Set("Content-Type", entry.ContentType)
+		}
+		if err := cfg.Execute(); err != nil {
+			// A request the API rejected is settled, and removed from the
+			// journal; only stop for errors that leave the entry pending.
+			current, getErr := store.Get(ctx, entry.IdempotencyKey)
+			if getErr != nil || (current != nil && current.Status == JournalStatusPending) {
+				return fmt.Errorf("error replaying %s %s: %w", entry.Method, entry.URL, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package requestconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultRedactedHeaders are the headers that are never logged.
+var DefaultRedactedHeaders = []string{"Authorization"}
+
+// DefaultRedactedFields are the JSON body fields that are never logged. A
+// field is redacted when its name equals an entry, or ends with an underscore
+// followed by an entry, so "account_number" also covers
+// "debtor_account_number".
+var DefaultRedactedFields = []string{
+	"primary_account_number",
+	"verification_code",
+	"account_number",
+	"routing_number",
+}
+
+const redacted = "[REDACTED]"
+
+// logAttempt logs a finished attempt. Request and response bodies are only
+// logged at the debug level, and the query of the URL, which can hold
+// credentials such as a presigned download signature, is never logged.
+func (cfg *RequestConfig) logAttempt(req *http.Request, res *http.Response, err error, attempt int, latency time.Duration) {
+	if cfg.Logger == nil {
+		return
+	}
+	ctx := req.Context()
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", attempt),
+		slog.Duration("latency", latency),
+	}
+	if key := req.Header.Get("Idempotency-Key"); key != "" {
+		attrs = append(attrs, slog.String("idempotency_key", key))
+	}
+	level := slog.LevelInfo
+	if res != nil {
+		attrs = append(attrs, slog.Int("status", res.StatusCode))
+		if res.StatusCode >= 400 {
+			level = slog.LevelWarn
+		}
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", redactError(err, req.URL)))
+		level = slog.LevelWarn
+	}
+
+	if cfg.Logger.Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs, slog.Any("request_headers", cfg.redactHeaders(req.Header)))
+		if len(cfg.Buffer) != 0 {
+			attrs = append(attrs, slog.String("request_body", cfg.redactBody(req.Header, cfg.Buffer)))
+		}
+		if res != nil {
+			attrs = append(attrs, slog.Any("response_headers", cfg.redactHeaders(res.Header)))
+			if body, ok := peekJSONBody(res); ok {
+				attrs = append(attrs, slog.String("response_body", cfg.redactBody(res.Header, body)))
+			}
+		}
+	}
+
+	cfg.Logger.LogAttrs(ctx, level, "increase request", attrs...)
+}
+
+// peekJSONBody reads a JSON response body and puts it back, so that it can
+// still be decoded afterwards.
+func peekJSONBody(res *http.Response) ([]byte, bool) {
+	if res.Body == nil || !strings.Contains(res.Header.Get("Content-Type"), "application/json") {
+		return nil, false
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	return body, err == nil
+}
+
+func (cfg *RequestConfig) redactHeaders(header http.Header) http.Header {
+	out := header.Clone()
+	for _, name := range append(DefaultRedactedHeaders, cfg.LogRedactedHeaders...) {
+		if out.Get(name) != "" {
+			out.Set(name, redacted)
+		}
+	}
+	return out
+}
+
+// redactBody returns the body with the values of all redacted fields replaced,
+// at any depth. Bodies that aren't JSON are not logged.
+func (cfg *RequestConfig) redactBody(header http.Header, body []byte) string {
+	if contentType := header.Get("Content-Type"); contentType != "" && !strings.Contains(contentType, "application/json") {
+		return "[" + contentType + "]"
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return "[invalid JSON]"
+	}
+	fields := append(DefaultRedactedFields, cfg.LogRedactedFields...)
+	redacted, _ := json.Marshal(redactValue(value, fields))
+	return string(redacted)
+}
+
+func redactValue(value interface{}, fields []string) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, inner := range value {
+			if isRedactedField(key, fields) {
+				value[key] = redacted
+			} else {
+				value[key] = redactValue(inner, fields)
+			}
+		}
+	case []interface{}:
+		for i, inner := range value {
+			value[i] = redactValue(inner, fields)
+		}
+	}
+	return value
+}
+
+func isRedactedField(key string, fields []string) bool {
+	key = strings.ToLower(key)
+	for _, field := range fields {
+		field = strings.ToLower(field)
+		if key == field || strings.HasSuffix(key, "_"+field) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package requestconfig
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestLoggingRedactsSensitiveData(t *testing.T) {
+	var out bytes.Buffer
+	cfg := newTestConfig(t, func(req *http.Request) (*http.Response, error) {
+		return newResponse(http.StatusOK, http.Header{"Content-Type": {"application/json"}},
+			`{"card_id":"card_123","primary_account_number":"4242424242424242","verification_code":"123","nested":[{"debtor_routing_number":"101050001"}]}`), nil
+	}, func(r *RequestConfig) error {
+		r.Logger = slog.New(slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		r.LogRedactedFields = []string{"card_id"}
+		r.Request.Header.Set("Authorization", "Bearer secret_key")
+		return nil
+	})
+	var body map[string]interface{}
+	cfg.ResponseBodyInto = &body
+	if err := cfg.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if body["primary_account_number"] != "4242424242424242" {
+		t.Fatalf("expected the response to be decoded unredacted, got %v", body)
+	}
+
+	logged := out.String()
+	for _, secret := range []string{"secret_key", "4242424242424242", `\"123\"`, "101050001", "card_123"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("expected %s to be redacted from %s", secret, logged)
+		}
+	}
+	for _, expected := range []string{`"method":"GET"`, `"path":"/accounts"`, `"status":200`, `"attempt":1`} {
+		if !strings.Contains(logged, expected) {
+			t.Errorf("expected %s to be logged in %s", expected, logged)
+		}
+	}
+}
+
+func TestLoggingRedactsURLInErrors(t *testing.T) {
+	var out bytes.Buffer
+	cfg := newTestConfig(t, func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New(`Get "` + req.URL.String() + `": connection reset`)
+	}, func(r *RequestConfig) error {
+		r.Logger = slog.New(slog.NewJSONHandler(&out, nil))
+		r.MaxRetries = 0
+		return nil
+	})
+	cfg.Request.URL, _ = url.Parse("https://files.increase.com/file_123?X-Amz-Signature=secret")
+	if err := cfg.Execute(); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	logged := out.String()
+	if strings.Contains(logged, "secret") {
+		t.Errorf("expected the query to be redacted from %s", logged)
+	}
+	if !strings.Contains(logged, "https://files.increase.com/file_123") || !strings.Contains(logged, "connection reset") {
+		t.Errorf("expected the error to be logged in %s", logged)
+	}
+}
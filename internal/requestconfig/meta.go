@@ -0,0 +1,84 @@
+package requestconfig
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ResponseMeta describes how a request was answered.
+type ResponseMeta struct {
+	// The status code and headers of the final response. Zero and nil if no
+	// response was received.
+	StatusCode int
+	Header     http.Header
+	// The ID the API assigned to the request, from the `X-Request-Id` header.
+	// Include it in support requests.
+	RequestID string
+	// The `Idempotency-Key` the request was sent with, if any.
+	IdempotencyKey string
+	// Whether the response is a replay of an earlier response to a request with
+	// the same idempotency key, rather than the outcome of this request.
+	IdempotentReplayed bool
+	// The rate limit state reported by the API, from the `X-RateLimit-Limit`,
+	// `X-RateLimit-Remaining` and `X-RateLimit-Reset` headers (or their
+	// `RateLimit-*` equivalents). -1 if not reported.
+	RateLimitLimit     int
+	RateLimitRemaining int
+	// Zero if not reported.
+	RateLimitReset time.Time
+	// The number of attempts made, including retries. Zero when the response was
+	// replayed from a journal.
+	Attempts int
+	// The time taken by the whole call, including retries and their delays.
+	Latency time.Duration
+}
+
+// countAttempts wraps the handler so that every attempt is counted into n.
+func countAttempts(handler middlewareNext, n *int) middlewareNext {
+	return func(req *http.Request) (*http.Response, error) {
+		*n++
+		return handler(req)
+	}
+}
+
+func (cfg *RequestConfig) fillResponseMeta(res *http.Response, attempts int, latency time.Duration) {
+	meta := ResponseMeta{
+		IdempotencyKey:     cfg.Request.Header.Get("Idempotency-Key"),
+		RateLimitLimit:     -1,
+		RateLimitRemaining: -1,
+		Attempts:           attempts,
+		Latency:            latency,
+	}
+	if res != nil {
+		meta.StatusCode = res.StatusCode
+		meta.Header = res.Header
+		meta.RequestID = res.Header.Get("X-Request-Id")
+		meta.IdempotentReplayed = res.Header.Get("Idempotent-Replayed") == "true"
+		if limit, ok := headerInt(res.Header, "X-RateLimit-Limit", "RateLimit-Limit"); ok {
+			meta.RateLimitLimit = limit
+		}
+		if remaining, ok := headerInt(res.Header, "X-RateLimit-Remaining", "RateLimit-Remaining"); ok {
+			meta.RateLimitRemaining = remaining
+		}
+		if reset, ok := headerInt(res.Header, "X-RateLimit-Reset", "RateLimit-Reset"); ok {
+			// Small values are a number of seconds from now, large ones a Unix
+			// timestamp.
+			if reset < 1_000_000_000 {
+				meta.RateLimitReset = time.Now().Add(time.Duration(reset) * time.Second)
+			} else {
+				meta.RateLimitReset = time.Unix(int64(reset), 0)
+			}
+		}
+	}
+	*cfg.ResponseMetaInto = meta
+}
+
+func headerInt(header http.Header, names ...string) (int, bool) {
+	for _, name := range names {
+		if value, err := strconv.Atoi(header.Get(name)); err == nil {
+			return value, true
+		}
+	}
+	return 0, false
+}
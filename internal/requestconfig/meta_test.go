@@ -0,0 +1,47 @@
+package requestconfig
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestExecuteFillsResponseMeta(t *testing.T) {
+	attempts := 0
+	var meta ResponseMeta
+	cfg := newTestConfig(t, func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return newResponse(http.StatusServiceUnavailable, nil, ""), nil
+		}
+		return newResponse(http.StatusNotFound, http.Header{
+			"Content-Type":          {"application/json"},
+			"X-Request-Id":          {"req_123"},
+			"X-Ratelimit-Limit":     {"100"},
+			"X-Ratelimit-Remaining": {"42"},
+			"X-Ratelimit-Reset":     {"30"},
+		}, `{"type":"object_not_found_error"}`), nil
+	}, func(r *RequestConfig) error {
+		r.RetryPolicy = &ConstantRetryPolicy{}
+		r.ResponseMetaInto = &meta
+		return nil
+	})
+
+	if err := cfg.Execute(); err == nil {
+		t.Fatal("expected an error")
+	}
+	if meta.StatusCode != http.StatusNotFound || meta.RequestID != "req_123" || meta.Attempts != 2 {
+		t.Fatalf("expected the metadata of the final response, got %+v", meta)
+	}
+	if meta.RateLimitLimit != 100 || meta.RateLimitRemaining != 42 || time.Until(meta.RateLimitReset) <= 0 {
+		t.Fatalf("expected the rate limit headers, got %+v", meta)
+	}
+	if meta.Latency <= 0 || meta.IdempotentReplayed {
+		t.Fatalf("unexpected metadata: %+v", meta)
+	}
+
+	next := cfg.Clone(cfg.Context)
+	if next.ResponseMetaInto != nil {
+		t.Fatal("expected a cloned config not to overwrite the metadata")
+	}
+}
@@ -8,12 +8,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"math"
-	"math/rand"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"reflect"
 	"runtime"
-	"strconv"
 	"strings"
 	"time"
 
@@ -22,6 +21,8 @@ import (
 	"github.com/increase/increase-go/internal/apierror"
 	"github.com/increase/increase-go/internal/apiform"
 	"github.com/increase/increase-go/internal/apiquery"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func getNormalizedOS() string {
@@ -73,19 +74,27 @@ func getPlatformProperties() map[string]string {
 
 func NewRequestConfig(ctx context.Context, method string, u string, body interface{}, dst interface{}, opts ...func(*RequestConfig) error) (*RequestConfig, error) {
 	var b []byte
+	var stream *apiform.Stream
 	contentType := "application/json"
 	if body, ok := body.(json.Marshaler); ok {
 		var err error
 		b, err = body.MarshalJSON()
 		if err != nil {
-			return nil, err
+			return nil, &apierror.EncodeError{Type: reflect.TypeOf(body), Err: err}
 		}
 	}
-	if body, ok := body.(apiform.Marshaler); ok {
+	if body, ok := body.(apiform.StreamMarshaler); ok {
+		var err error
+		stream, err = body.MarshalMultipartStream()
+		if err != nil {
+			return nil, &apierror.EncodeError{Type: reflect.TypeOf(body), Err: err}
+		}
+		contentType = stream.ContentType()
+	} else if body, ok := body.(apiform.Marshaler); ok {
 		var err error
 		b, contentType, err = body.MarshalMultipart()
 		if err != nil {
-			return nil, err
+			return nil, &apierror.EncodeError{Type: reflect.TypeOf(body), Err: err}
 		}
 	}
 	if body, ok := body.(apiquery.Queryer); ok {
@@ -95,10 +104,12 @@ func NewRequestConfig(ctx context.Context, method string, u string, body interfa
 	if err != nil {
 		return nil, err
 	}
-	if b != nil {
+	if b != nil || stream != nil {
 		req.Header.Set("Content-Type", contentType)
 	}
-	req.Header.Set("Idempotency-Key", "stainless-go-"+uuid.New().String())
+	if IsMutating(method) {
+		req.Header.Set("Idempotency-Key", "stainless-go-"+uuid.New().String())
+	}
 	req.Header.Set("Accept", "application/json")
 
 	for k, v := range getPlatformProperties() {
@@ -108,14 +119,18 @@ func NewRequestConfig(ctx context.Context, method string, u string, body interfa
 		MaxRetries: 2,
 		Context:    ctx,
 		Request:    req,
-		HTTPClient: http.DefaultClient,
+		HTTPClient: defaultHTTPClient(),
 		Buffer:     b,
+		Stream:     stream,
 	}
 	cfg.ResponseBodyInto = dst
 	err = cfg.Apply(opts...)
 	if err != nil {
 		return nil, err
 	}
+	if cfg.instrumented() {
+		cfg.Operation = operationName()
+	}
 	return &cfg, nil
 }
 
@@ -131,7 +146,40 @@ type RequestConfig struct {
 	BaseURL        *url.URL
 	HTTPClient     *http.Client
 	Middlewares    []middleware
-	APIKey         string
+	// Preflight checks run once, before the request is sent. An error they
+	// return fails the request as it is, without retries.
+	Preflight []func(*http.Request) error
+	// SimulationHosts are the hosts other than the sandbox that simulations may
+	// be sent to, such as mock servers.
+	SimulationHosts []string
+	APIKey          string
+	// IdempotencyKey is the idempotency key supplied by the caller. If empty, a
+	// random key is sent with mutating requests.
+	IdempotencyKey string
+	// Journal records mutating requests sent with an IdempotencyKey, and replays
+	// their responses when they are made again.
+	Journal JournalStore
+	// Logger receives a record for every request attempt. Request and response
+	// bodies are logged at the debug level, with sensitive fields redacted.
+	Logger *slog.Logger
+	// LogRedactedHeaders and LogRedactedFields are redacted from the logs, in
+	// addition to [DefaultRedactedHeaders] and [DefaultRedactedFields].
+	LogRedactedHeaders []string
+	LogRedactedFields  []string
+	// TracerProvider and MeterProvider receive a span and metrics for every
+	// operation, and a child span for each of its attempts.
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+	// Operation is the name of the SDK method making the request, such as
+	// "ACHTransferService.New". It is only looked up when the request is traced
+	// or measured.
+	Operation string
+	// Prefetch is the number of pages of a list that auto-paging fetches ahead
+	// of the caller, in the background. Zero fetches each page when needed.
+	Prefetch int
+	// RetryPolicy decides which failed attempts are retried and how long to wait
+	// in between. If nil, [DefaultRetryPolicy] is used.
+	RetryPolicy RetryPolicy
 	// If ResponseBodyInto not nil, then we will attempt to deserialize into
 	// ResponseBodyInto. If Destination is a []byte, then it will return the body as
 	// is.
@@ -139,7 +187,13 @@ type RequestConfig struct {
 	// ResponseInto copies the \*http.Response of the corresponding request into the
 	// given address
 	ResponseInto **http.Response
-	Buffer       []byte
+	// ResponseMetaInto receives the metadata of the response, even if the request
+	// failed.
+	ResponseMetaInto *ResponseMeta
+	Buffer           []byte
+	// Stream is a multipart body that is encoded while it is sent, instead of
+	// being held in Buffer.
+	Stream *apiform.Stream
 }
 
 // middleware is exactly the same type as the Middleware type found in the [option] package,
@@ -156,92 +210,63 @@ func applyMiddleware(middleware middleware, next middlewareNext) middlewareNext
 	}
 }
 
-func shouldRetry(req *http.Request, res *http.Response) bool {
-	// If there is no way to recover the Body, then we shouldn't retry.
-	if req.Body != nil && req.GetBody == nil {
-		return false
-	}
-
-	// If there is no response, that indicates that there is a connection error
-	// so we retry the request.
-	if res == nil {
-		return true
-	}
-
-	// If the header explictly wants a retry behavior, respect that over the
-	// http status code.
-	if res.Header.Get("x-should-retry") == "true" {
-		return true
-	}
-	if res.Header.Get("x-should-retry") == "false" {
-		return false
-	}
-
-	return res.StatusCode == http.StatusRequestTimeout ||
-		res.StatusCode == http.StatusConflict ||
-		res.StatusCode == http.StatusTooManyRequests ||
-		res.StatusCode >= http.StatusInternalServerError
-}
-
-func retryDelay(res *http.Response, retryCount int) time.Duration {
-	maxDelay := 8 * time.Second
-	delay := time.Duration(0.5 * float64(time.Second) * math.Pow(2, float64(retryCount)))
-	if res != nil {
-		if parsed, err := strconv.ParseInt(res.Header.Get("Retry-After"), 10, 64); err == nil {
-			delay = time.Duration(parsed) * time.Second
-		}
-	}
-	if delay > maxDelay {
-		delay = maxDelay
-	}
-	jitter := rand.Int63n(int64(delay / 4))
-	delay -= time.Duration(jitter)
-	return delay
-}
-
 func (cfg *RequestConfig) Execute() (err error) {
 	cfg.Request.URL, err = cfg.BaseURL.Parse(cfg.Request.URL.String())
 	if err != nil {
 		return err
 	}
+	for _, check := range cfg.Preflight {
+		if err := check(cfg.Request); err != nil {
+			return err
+		}
+	}
 
 	if len(cfg.Buffer) != 0 && cfg.Request.Body == nil {
 		cfg.Request.ContentLength = int64(len(cfg.Buffer))
 		cfg.Request.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(cfg.Buffer)), nil }
 		cfg.Request.Body, _ = cfg.Request.GetBody()
 	}
+	if cfg.Stream != nil && cfg.Request.Body == nil {
+		cfg.Request.ContentLength = cfg.Stream.ContentLength()
+		// Without GetBody, the request is not retried once the body was sent.
+		if cfg.Stream.Rewindable() {
+			cfg.Request.GetBody = func() (io.ReadCloser, error) { return cfg.Stream.Body(), nil }
+		}
+		// The stream is only opened once an attempt reads the body, as a
+		// middleware may fail the request without sending it.
+		cfg.Request.Body = cfg.Stream.Body()
+	}
 
-	handler := cfg.HTTPClient.Do
+	handler := cfg.do
 	for i := len(cfg.Middlewares) - 1; i >= 0; i -= 1 {
 		handler = applyMiddleware(cfg.Middlewares[i], handler)
 	}
 
-	var res *http.Response
-	for retryCount := 0; retryCount <= cfg.MaxRetries; retryCount += 1 {
-		ctx := cfg.Request.Context()
-		if cfg.RequestTimeout != time.Duration(0) {
-			var cancel context.CancelFunc
-			ctx, cancel = context.WithTimeout(ctx, cfg.RequestTimeout)
-			defer cancel()
-		}
+	if cfg.instrumented() {
+		var end func(error)
+		handler, end = cfg.instrument(handler)
+		defer func() { end(err) }()
+	}
 
-		res, err = handler(cfg.Request.Clone(ctx))
-		if ctx != nil && ctx.Err() != nil {
-			return ctx.Err()
-		}
-		if !shouldRetry(cfg.Request, res) || retryCount >= cfg.MaxRetries {
-			break
+	var res *http.Response
+	if cfg.ResponseMetaInto != nil {
+		attempts, start := 0, time.Now()
+		handler = countAttempts(handler, &attempts)
+		defer func() { cfg.fillResponseMeta(res, attempts, time.Since(start)) }()
+	}
+	if cfg.journaled() {
+		res, err = cfg.beginJournalEntry()
+		if err != nil {
+			return err
 		}
-
-		// Prepare next request and wait for the retry delay
-		if cfg.Request.GetBody != nil {
-			cfg.Request.Body, err = cfg.Request.GetBody()
-			if err != nil {
-				return err
+	}
+	if res == nil {
+		res, err = cfg.send(handler)
+		if cfg.journaled() {
+			if jerr := cfg.completeJournalEntry(res, err); jerr != nil {
+				return jerr
 			}
 		}
-
-		time.Sleep(retryDelay(res, retryCount))
 	}
 
 	if err != nil {
@@ -249,19 +274,14 @@ func (cfg *RequestConfig) Execute() (err error) {
 	}
 
 	if res.StatusCode >= 400 {
-		aerr := apierror.Error{Request: cfg.Request, Response: res, StatusCode: res.StatusCode}
 		contents, err := io.ReadAll(res.Body)
+		res.Body.Close()
 		if err != nil {
-			return err
-		}
-		// If there is an APIError, re-populate the response body so that debugging
-		// utilities can conveniently dump the response without issue.
-		res.Body = io.NopCloser(bytes.NewBuffer(contents))
-		err = aerr.UnmarshalJSON(contents)
-		if err != nil {
-			return err
+			return &apierror.ConnectionError{Request: cfg.Request, Err: err}
 		}
-		return &aerr
+		// The error keeps the body, so that it can be logged and dumped any number
+		// of times.
+		return apierror.NewError(cfg.Request, res, contents)
 	}
 
 	if cfg.ResponseInto != nil {
@@ -269,6 +289,9 @@ func (cfg *RequestConfig) Execute() (err error) {
 	}
 
 	if cfg.ResponseBodyInto == nil {
+		if cfg.ResponseInto == nil {
+			res.Body.Close()
+		}
 		return nil
 	}
 
@@ -277,9 +300,16 @@ func (cfg *RequestConfig) Execute() (err error) {
 		return nil
 	}
 
+	// The caller reads (and closes) the body itself, without it being buffered.
+	if responseBodyInto, ok := cfg.ResponseBodyInto.(*io.ReadCloser); ok {
+		*responseBodyInto = cfg.streamBody(res, handler)
+		return nil
+	}
+
 	contents, err := io.ReadAll(res.Body)
+	res.Body.Close()
 	if err != nil {
-		return fmt.Errorf("error reading response body: %w", err)
+		return &apierror.ConnectionError{Request: cfg.Request, Err: fmt.Errorf("error reading response body: %w", err)}
 	}
 
 	// If we are not json return plaintext
@@ -294,19 +324,146 @@ func (cfg *RequestConfig) Execute() (err error) {
 		case *[]byte:
 			*dst = contents
 		default:
-			return fmt.Errorf("expected destination type of 'string' or '[]byte' for responses with content-type that is not 'application/json'")
+			return &apierror.DecodeError{
+				Body: contents,
+				Type: reflect.TypeOf(cfg.ResponseBodyInto),
+				Err:  fmt.Errorf("expected destination type of 'string' or '[]byte' for responses with content-type that is not 'application/json'"),
+			}
 		}
 		return nil
 	}
 
 	err = json.NewDecoder(bytes.NewReader(contents)).Decode(cfg.ResponseBodyInto)
 	if err != nil {
-		err = fmt.Errorf("error parsing response json: %w", err)
+		return &apierror.DecodeError{Body: contents, Type: reflect.TypeOf(cfg.ResponseBodyInto), Err: err}
 	}
 
 	return nil
 }
 
+// send makes the request, retrying failed attempts as decided by the retry
+// policy, and returns the final response.
+func (cfg *RequestConfig) send(handler middlewareNext) (res *http.Response, err error) {
+	policy := cfg.RetryPolicy
+	if policy == nil {
+		policy = DefaultRetryPolicy()
+	}
+
+	var delay time.Duration
+	var cancel context.CancelFunc
+	start := time.Now()
+	for retryCount := 0; retryCount <= cfg.MaxRetries; retryCount += 1 {
+		ctx := cfg.Request.Context()
+		cancel = func() {}
+		if cfg.RequestTimeout != time.Duration(0) {
+			ctx, cancel = context.WithTimeout(ctx, cfg.RequestTimeout)
+		}
+
+		req := cfg.Request.Clone(ctx)
+		attemptStart := time.Now()
+		res, err = handler(req)
+		cfg.logAttempt(req, res, err, retryCount+1, time.Since(attemptStart))
+		// Only give up when the overall context is done; an attempt that ran into
+		// the per-attempt timeout is retried like any other connection error.
+		if parent := cfg.Request.Context(); parent.Err() != nil {
+			cancel()
+			return nil, &apierror.ConnectionError{Request: cfg.Request, Err: parent.Err()}
+		}
+		// If there is no way to recover the Body, then we shouldn't retry.
+		if retryCount >= cfg.MaxRetries || (cfg.Request.Body != nil && cfg.Request.GetBody == nil) {
+			break
+		}
+
+		attempt := RetryAttempt{
+			Request:       cfg.Request,
+			Response:      res,
+			Err:           err,
+			RetryCount:    retryCount,
+			PreviousDelay: delay,
+			Elapsed:       time.Since(start),
+		}
+		if res != nil && res.StatusCode >= 400 {
+			attempt.Err = peekAPIError(cfg.Request, res)
+		}
+		if !policy.ShouldRetry(attempt) {
+			break
+		}
+		delay = policy.RetryDelay(attempt)
+		if budget := policy.MaxElapsedTime(); budget > 0 && attempt.Elapsed+delay > budget {
+			break
+		}
+		// Don't start a retry that the overall deadline would cut short anyway.
+		if deadline, ok := cfg.Request.Context().Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			break
+		}
+
+		// Release this attempt before waiting, rather than when the call returns.
+		if res != nil && res.Body != nil {
+			io.Copy(io.Discard, io.LimitReader(res.Body, 4096))
+			res.Body.Close()
+		}
+		cancel()
+
+		// Prepare next request and wait for the retry delay
+		if cfg.Request.GetBody != nil {
+			cfg.Request.Body, err = cfg.Request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if err = sleep(cfg.Request.Context(), delay); err != nil {
+			return nil, &apierror.ConnectionError{Request: cfg.Request, Err: err}
+		}
+	}
+
+	// The last attempt's timeout has to outlive Execute when the caller reads the
+	// body itself, so it is released when the body is closed.
+	if res == nil || res.Body == nil {
+		cancel()
+	} else {
+		res.Body = &cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+	}
+	return res, err
+}
+
+// do sends a single attempt. Only the errors of the HTTP client are connection
+// errors; those of middleware, such as an open circuit breaker, are returned
+// as they are.
+func (cfg *RequestConfig) do(req *http.Request) (*http.Response, error) {
+	res, err := cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, &apierror.ConnectionError{Request: cfg.Request, Err: err}
+	}
+	return res, nil
+}
+
+// sleep waits for the given duration, returning early with the context's error
+// if it is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// cancelOnClose releases the context of the attempt that produced a response
+// body once the body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
 func ExecuteNewRequest(ctx context.Context, method string, u string, body interface{}, dst interface{}, opts ...func(*RequestConfig) error) error {
 	cfg, err := NewRequestConfig(ctx, method, u, body, dst, opts...)
 	if err != nil {
@@ -327,16 +484,32 @@ func (cfg *RequestConfig) Clone(ctx context.Context) *RequestConfig {
 	if err != nil {
 		return nil
 	}
-	new := &RequestConfig{
-		MaxRetries: cfg.MaxRetries,
-		Context:    ctx,
-		Request:    req,
-		HTTPClient: cfg.HTTPClient,
+	// Keep every setting, such as the middleware, but none of the state of the
+	// original request.
+	new := &RequestConfig{}
+	*new = *cfg
+	new.Context = ctx
+	new.Request = req
+	new.ResponseBodyInto = nil
+	new.ResponseInto = nil
+	new.ResponseMetaInto = nil
+	if IsMutating(req.Method) {
+		new.Request.Header.Set("Idempotency-Key", "stainless-go-"+uuid.New().String())
 	}
-	new.Request.Header.Set("Idempotency-Key", "stainless-go-"+uuid.New().String())
 	return new
 }
 
+// IsMutating reports whether requests with the given method may change state,
+// and therefore carry an `Idempotency-Key`.
+func IsMutating(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
+
 func (cfg *RequestConfig) Apply(opts ...func(*RequestConfig) error) error {
 	for _, opt := range opts {
 		err := opt(cfg)
@@ -0,0 +1,249 @@
+package requestconfig
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/increase/increase-go/internal/apierror"
+)
+
+// RetryAttempt describes a finished request attempt that a [RetryPolicy] is
+// asked to judge.
+type RetryAttempt struct {
+	// The request that was sent.
+	Request *http.Request
+	// The response that was received, or nil if the request failed before a
+	// response was received.
+	Response *http.Response
+	// The transport error, or the decoded *apierror.Error when the API responded
+	// with an error status code.
+	Err error
+	// The number of retries that have already been made. This is 0 after the first
+	// attempt.
+	RetryCount int
+	// The delay that was waited before this attempt, or 0 for the first attempt.
+	PreviousDelay time.Duration
+	// The time elapsed since the first attempt was started.
+	Elapsed time.Duration
+}
+
+// RetryPolicy decides whether a failed request attempt should be retried, and
+// how long to wait before doing so. The number of retries is bounded separately
+// by MaxRetries.
+type RetryPolicy interface {
+	// ShouldRetry reports whether the given attempt should be retried.
+	ShouldRetry(attempt RetryAttempt) bool
+	// RetryDelay returns how long to wait before retrying the given attempt.
+	RetryDelay(attempt RetryAttempt) time.Duration
+	// MaxElapsedTime returns the total time budget for a request, including all
+	// retries and the delays between them. A retry whose delay would exceed the
+	// budget is not made. Zero means there is no budget.
+	MaxElapsedTime() time.Duration
+}
+
+// DefaultShouldRetry retries connection errors, 408 Request Timeout, 409
+// Conflict, 429 Rate Limit and >=500 Internal errors, unless the response
+// carries an explicit `x-should-retry` header.
+func DefaultShouldRetry(attempt RetryAttempt) bool {
+	res := attempt.Response
+
+	// Errors can opt out of being retried, for example when a circuit breaker
+	// fails the request fast.
+	var retryable interface{ Retryable() bool }
+	if errors.As(attempt.Err, &retryable) && !retryable.Retryable() {
+		return false
+	}
+
+	// If there is no response, that indicates that there is a connection error
+	// so we retry the request.
+	if res == nil {
+		return true
+	}
+
+	// If the header explictly wants a retry behavior, respect that over the
+	// http status code.
+	if res.Header.Get("x-should-retry") == "true" {
+		return true
+	}
+	if res.Header.Get("x-should-retry") == "false" {
+		return false
+	}
+
+	return res.StatusCode == http.StatusRequestTimeout ||
+		res.StatusCode == http.StatusConflict ||
+		res.StatusCode == http.StatusTooManyRequests ||
+		res.StatusCode >= http.StatusInternalServerError
+}
+
+// RetryAfter returns the delay the API asked for before retrying, taken from
+// the `Retry-After` header (either delay-seconds or an HTTP-date) or, failing
+// that, from the `retry_after` field of the error body.
+func RetryAfter(attempt RetryAttempt) (time.Duration, bool) {
+	if attempt.Response != nil {
+		if header := strings.TrimSpace(attempt.Response.Header.Get("Retry-After")); header != "" {
+			if seconds, err := strconv.ParseInt(header, 10, 64); err == nil && seconds >= 0 {
+				return time.Duration(seconds) * time.Second, true
+			}
+			if date, err := http.ParseTime(header); err == nil {
+				delay := time.Until(date)
+				if delay < 0 {
+					delay = 0
+				}
+				return delay, true
+			}
+		}
+	}
+	if aerr, ok := attempt.Err.(*apierror.Error); ok && aerr.RetryAfter > 0 {
+		return time.Duration(aerr.RetryAfter) * time.Second, true
+	}
+	return 0, false
+}
+
+// DefaultRetryPolicy returns the policy used when none is configured: an
+// exponential backoff starting at 0.5 seconds and capped at 8 seconds.
+func DefaultRetryPolicy() RetryPolicy {
+	return &ExponentialRetryPolicy{}
+}
+
+// ExponentialRetryPolicy waits InitialDelay before the first retry and
+// multiplies the delay by Multiplier for every following retry, up to MaxDelay.
+// Up to a quarter of each delay is removed at random to spread out retries. A
+// delay requested by the API is used as a lower bound, and is not capped.
+type ExponentialRetryPolicy struct {
+	// Defaults to 0.5 seconds.
+	InitialDelay time.Duration
+	// Defaults to 8 seconds.
+	MaxDelay time.Duration
+	// Defaults to 2.
+	Multiplier float64
+	// Zero means there is no budget.
+	MaxElapsed time.Duration
+}
+
+func (p *ExponentialRetryPolicy) ShouldRetry(attempt RetryAttempt) bool {
+	return DefaultShouldRetry(attempt)
+}
+
+func (p *ExponentialRetryPolicy) RetryDelay(attempt RetryAttempt) time.Duration {
+	initial := p.InitialDelay
+	if initial <= 0 {
+		initial = 500 * time.Millisecond
+	}
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = 8 * time.Second
+	}
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	delay := time.Duration(float64(initial) * math.Pow(multiplier, float64(attempt.RetryCount)))
+	if delay > maxDelay || delay < 0 {
+		delay = maxDelay
+	}
+	if delay/4 > 0 {
+		delay -= time.Duration(rand.Int63n(int64(delay / 4)))
+	}
+	if hint, ok := RetryAfter(attempt); ok && hint > delay {
+		delay = hint
+	}
+	return delay
+}
+
+func (p *ExponentialRetryPolicy) MaxElapsedTime() time.Duration {
+	return p.MaxElapsed
+}
+
+// DecorrelatedJitterRetryPolicy picks each delay at random between BaseDelay and
+// three times the previous delay, capped at MaxDelay. Compared to exponential
+// backoff, it spreads out clients that started retrying at the same moment. A
+// delay requested by the API is used as a lower bound, and is not capped.
+type DecorrelatedJitterRetryPolicy struct {
+	// Defaults to 0.5 seconds.
+	BaseDelay time.Duration
+	// Defaults to 8 seconds.
+	MaxDelay time.Duration
+	// Zero means there is no budget.
+	MaxElapsed time.Duration
+}
+
+func (p *DecorrelatedJitterRetryPolicy) ShouldRetry(attempt RetryAttempt) bool {
+	return DefaultShouldRetry(attempt)
+}
+
+func (p *DecorrelatedJitterRetryPolicy) RetryDelay(attempt RetryAttempt) time.Duration {
+	base := p.BaseDelay
+	if base <= 0 {
+		base = 500 * time.Millisecond
+	}
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = 8 * time.Second
+	}
+
+	previous := attempt.PreviousDelay
+	if previous < base {
+		previous = base
+	}
+	delay := base
+	if upper := previous * 3; upper > base {
+		delay += time.Duration(rand.Int63n(int64(upper - base)))
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	if hint, ok := RetryAfter(attempt); ok && hint > delay {
+		delay = hint
+	}
+	return delay
+}
+
+func (p *DecorrelatedJitterRetryPolicy) MaxElapsedTime() time.Duration {
+	return p.MaxElapsed
+}
+
+// ConstantRetryPolicy waits the same Delay before every retry, or longer if the
+// API asks for it.
+type ConstantRetryPolicy struct {
+	Delay time.Duration
+	// Zero means there is no budget.
+	MaxElapsed time.Duration
+}
+
+func (p *ConstantRetryPolicy) ShouldRetry(attempt RetryAttempt) bool {
+	return DefaultShouldRetry(attempt)
+}
+
+func (p *ConstantRetryPolicy) RetryDelay(attempt RetryAttempt) time.Duration {
+	if hint, ok := RetryAfter(attempt); ok && hint > p.Delay {
+		return hint
+	}
+	return p.Delay
+}
+
+func (p *ConstantRetryPolicy) MaxElapsedTime() time.Duration {
+	return p.MaxElapsed
+}
+
+// peekAPIError decodes the error body of res without consuming it, so that the
+// retry policy can look at fields such as `retry_after`.
+func peekAPIError(req *http.Request, res *http.Response) error {
+	if res.Body == nil {
+		return apierror.NewError(req, res, nil)
+	}
+	contents, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		res.Body = io.NopCloser(bytes.NewReader(contents))
+		return err
+	}
+	return apierror.NewError(req, res, contents)
+}
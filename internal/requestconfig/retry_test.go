@@ -0,0 +1,247 @@
+package requestconfig
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func newResponse(status int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func newTestConfig(t *testing.T, transport roundTripFunc, opts ...func(*RequestConfig) error) *RequestConfig {
+	t.Helper()
+	base, _ := url.Parse("https://api.increase.com/")
+	opts = append([]func(*RequestConfig) error{func(r *RequestConfig) error {
+		r.BaseURL = base
+		r.HTTPClient = &http.Client{Transport: transport}
+		return nil
+	}}, opts...)
+	cfg, err := NewRequestConfig(context.Background(), http.MethodGet, "accounts", nil, nil, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := map[string]struct {
+		attempt RetryAttempt
+		delay   time.Duration
+		ok      bool
+	}{
+		"seconds": {
+			RetryAttempt{Response: newResponse(429, http.Header{"Retry-After": {"3"}}, "")},
+			3 * time.Second, true,
+		},
+		"past date": {
+			RetryAttempt{Response: newResponse(429, http.Header{"Retry-After": {"Wed, 21 Oct 2015 07:28:00 GMT"}}, "")},
+			0, true,
+		},
+		"error body": {
+			RetryAttempt{Response: newResponse(429, nil, ""), Err: peekAPIError(nil, newResponse(429, nil, `{"retry_after":7}`))},
+			7 * time.Second, true,
+		},
+		"missing": {
+			RetryAttempt{Response: newResponse(500, nil, "")},
+			0, false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			delay, ok := RetryAfter(test.attempt)
+			if delay != test.delay || ok != test.ok {
+				t.Fatalf("expected (%s, %v), got (%s, %v)", test.delay, test.ok, delay, ok)
+			}
+		})
+	}
+
+	future := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	delay, ok := RetryAfter(RetryAttempt{Response: newResponse(503, http.Header{"Retry-After": {future}}, "")})
+	if !ok || delay <= 50*time.Second || delay > time.Minute {
+		t.Fatalf("expected a delay of about a minute for an HTTP-date, got %s", delay)
+	}
+}
+
+func TestExponentialRetryPolicyDelay(t *testing.T) {
+	policy := &ExponentialRetryPolicy{InitialDelay: time.Second, MaxDelay: 5 * time.Second}
+	for retryCount, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+		delay := policy.RetryDelay(RetryAttempt{RetryCount: retryCount})
+		if delay > expected || delay < expected*3/4 {
+			t.Errorf("retry %d: expected a delay between %s and %s, got %s", retryCount, expected*3/4, expected, delay)
+		}
+	}
+}
+
+func TestRetryDelayHonorsRetryAfter(t *testing.T) {
+	policies := map[string]RetryPolicy{
+		"exponential":         &ExponentialRetryPolicy{},
+		"decorrelated jitter": &DecorrelatedJitterRetryPolicy{},
+		"constant":            &ConstantRetryPolicy{Delay: time.Second},
+	}
+	for name, policy := range policies {
+		t.Run(name, func(t *testing.T) {
+			for _, hint := range []string{"1", "3", "20"} {
+				expected, _ := time.ParseDuration(hint + "s")
+				for retryCount := 0; retryCount < 10; retryCount++ {
+					attempt := RetryAttempt{
+						RetryCount: retryCount,
+						Response:   newResponse(429, http.Header{"Retry-After": {hint}}, ""),
+					}
+					if delay := policy.RetryDelay(attempt); delay < expected {
+						t.Fatalf("retry %d: expected a delay of at least %s, got %s", retryCount, expected, delay)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDecorrelatedJitterRetryPolicyDelay(t *testing.T) {
+	policy := &DecorrelatedJitterRetryPolicy{BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+	previous := time.Duration(0)
+	for i := 0; i < 20; i++ {
+		delay := policy.RetryDelay(RetryAttempt{RetryCount: i, PreviousDelay: previous})
+		if delay < time.Second || delay > 10*time.Second {
+			t.Fatalf("expected a delay between 1s and 10s, got %s", delay)
+		}
+		previous = delay
+	}
+}
+
+func TestExecuteUsesRetryPolicy(t *testing.T) {
+	attempts := 0
+	cfg := newTestConfig(t, func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return newResponse(http.StatusServiceUnavailable, nil, `{}`), nil
+	}, func(r *RequestConfig) error {
+		r.MaxRetries = 3
+		r.RetryPolicy = &ConstantRetryPolicy{Delay: time.Millisecond}
+		return nil
+	})
+	if err := cfg.Execute(); err == nil {
+		t.Fatal("expected an error")
+	}
+	if attempts != 4 {
+		t.Fatalf("expected 4 attempts, got %d", attempts)
+	}
+}
+
+func TestExecuteRespectsMaxElapsedTime(t *testing.T) {
+	attempts := 0
+	cfg := newTestConfig(t, func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return newResponse(http.StatusInternalServerError, nil, `{}`), nil
+	}, func(r *RequestConfig) error {
+		r.MaxRetries = 10
+		r.RetryPolicy = &ConstantRetryPolicy{Delay: 20 * time.Millisecond, MaxElapsed: 50 * time.Millisecond}
+		return nil
+	})
+	cfg.Execute()
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts within the budget, got %d", attempts)
+	}
+}
+
+func TestExecuteCancelDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cfg := newTestConfig(t, func(req *http.Request) (*http.Response, error) {
+		time.AfterFunc(10*time.Millisecond, cancel)
+		return newResponse(http.StatusServiceUnavailable, nil, `{}`), nil
+	}, func(r *RequestConfig) error {
+		r.Request = r.Request.WithContext(ctx)
+		r.RetryPolicy = &ConstantRetryPolicy{Delay: time.Minute}
+		return nil
+	})
+	start := time.Now()
+	if err := cfg.Execute(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the backoff to be interrupted, but Execute took %s", elapsed)
+	}
+}
+
+func TestExecuteSkipsRetryPastDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	attempts := 0
+	cfg := newTestConfig(t, func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return newResponse(http.StatusServiceUnavailable, nil, `{}`), nil
+	}, func(r *RequestConfig) error {
+		r.Request = r.Request.WithContext(ctx)
+		r.RetryPolicy = &ConstantRetryPolicy{Delay: time.Minute}
+		return nil
+	})
+	start := time.Now()
+	err := cfg.Execute()
+	if err == nil || errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the API error of the only attempt, got %v", err)
+	}
+	if attempts != 1 || time.Since(start) > 500*time.Millisecond {
+		t.Fatalf("expected a single attempt without waiting, got %d attempts in %s", attempts, time.Since(start))
+	}
+}
+
+func TestExecuteRetriesAttemptTimeout(t *testing.T) {
+	attempts := 0
+	cfg := newTestConfig(t, func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		}
+		return newResponse(http.StatusOK, http.Header{"Content-Type": {"application/json"}}, `{}`), nil
+	}, func(r *RequestConfig) error {
+		r.RequestTimeout = 10 * time.Millisecond
+		r.RetryPolicy = &ConstantRetryPolicy{Delay: time.Millisecond}
+		return nil
+	})
+	if err := cfg.Execute(); err != nil {
+		t.Fatalf("expected the retry to succeed, got %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+type permanentError struct{}
+
+func (permanentError) Error() string   { return "permanent" }
+func (permanentError) Retryable() bool { return false }
+
+func TestExecuteDoesNotRetryPermanentErrors(t *testing.T) {
+	attempts := 0
+	cfg := newTestConfig(t, nil, func(r *RequestConfig) error {
+		r.Middlewares = append(r.Middlewares, func(req *http.Request, next middlewareNext) (*http.Response, error) {
+			attempts++
+			return nil, permanentError{}
+		})
+		r.RetryPolicy = &ConstantRetryPolicy{Delay: time.Millisecond}
+		return nil
+	})
+	if err := cfg.Execute(); !errors.As(err, &permanentError{}) {
+		t.Fatalf("expected the middleware's error, got %v", err)
+	}
+	if attempts != 1 {
+		t.Fatalf("expected a single attempt, got %d", attempts)
+	}
+}
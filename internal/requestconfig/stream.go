@@ -0,0 +1,128 @@
+package requestconfig
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// streamBody returns the body of a successful response for the caller to read.
+// Retries only apply up to the response headers; if reading a GET response
+// fails halfway, the rest of the body is requested again with an HTTP Range
+// request, up to MaxRetries times.
+func (cfg *RequestConfig) streamBody(res *http.Response, handler middlewareNext) io.ReadCloser {
+	if cfg.Request.Method != http.MethodGet || res.Uncompressed {
+		return res.Body
+	}
+	return &resumableBody{
+		cfg:       cfg,
+		handler:   handler,
+		body:      res.Body,
+		total:     res.ContentLength,
+		validator: rangeValidator(res.Header),
+	}
+}
+
+// rangeValidator returns the value for the `If-Range` header that makes sure a
+// resumed download continues the same representation: the response's strong
+// ETag, or else its Last-Modified date.
+func rangeValidator(header http.Header) string {
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return header.Get("Last-Modified")
+}
+
+type resumableBody struct {
+	cfg       *RequestConfig
+	handler   middlewareNext
+	body      io.ReadCloser
+	offset    int64
+	total     int64
+	validator string
+	resumes   int
+	closed    bool
+}
+
+func (b *resumableBody) Read(p []byte) (int, error) {
+	for {
+		n, err := b.body.Read(p)
+		b.offset += int64(n)
+		if err == nil || errors.Is(err, io.EOF) || b.closed || b.resumes >= b.cfg.MaxRetries || b.cfg.Request.Context().Err() != nil {
+			return n, err
+		}
+		if rerr := b.resume(); rerr != nil {
+			return n, fmt.Errorf("%w (resuming the download failed: %v)", err, rerr)
+		}
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+// resume replaces the body with the remainder of the download, from the
+// current offset.
+func (b *resumableBody) resume() error {
+	b.resumes++
+	b.body.Close()
+	b.body = http.NoBody
+
+	cfg := *b.cfg
+	cfg.Request = b.cfg.Request.Clone(b.cfg.Request.Context())
+	cfg.Request.Header.Set("Range", fmt.Sprintf("bytes=%d-", b.offset))
+	if b.validator != "" {
+		cfg.Request.Header.Set("If-Range", b.validator)
+	}
+	res, err := cfg.send(b.handler)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusPartialContent {
+		res.Body.Close()
+		return fmt.Errorf("expected 206 Partial Content, got %d", res.StatusCode)
+	}
+	start, total, ok := parseContentRange(res.Header.Get("Content-Range"))
+	if !ok || start != b.offset || (b.total >= 0 && total >= 0 && total != b.total) {
+		res.Body.Close()
+		return fmt.Errorf("unexpected Content-Range %q", res.Header.Get("Content-Range"))
+	}
+	b.body = res.Body
+	return nil
+}
+
+func (b *resumableBody) Close() error {
+	b.closed = true
+	return b.body.Close()
+}
+
+// parseContentRange parses a `Content-Range` header such as
+// "bytes 100-999/1000", returning -1 for an unknown total ("bytes 100-999/*").
+func parseContentRange(header string) (start int64, total int64, ok bool) {
+	spec, found := strings.CutPrefix(header, "bytes ")
+	if !found {
+		return 0, 0, false
+	}
+	byteRange, size, found := strings.Cut(spec, "/")
+	if !found {
+		return 0, 0, false
+	}
+	first, _, found := strings.Cut(byteRange, "-")
+	if !found {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if size == "*" {
+		return start, -1, true
+	}
+	total, err = strconv.ParseInt(size, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, total, true
+}
@@ -0,0 +1,186 @@
+package requestconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/increase/increase-go/internal/apiform"
+)
+
+// brokenReader returns the given data, and then fails as if the connection was
+// reset.
+type brokenReader struct {
+	io.Reader
+}
+
+func (r brokenReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if errors.Is(err, io.EOF) {
+		return n, errors.New("connection reset by peer")
+	}
+	return n, err
+}
+
+func TestExecuteStreamsAndResumesBody(t *testing.T) {
+	const content = "0123456789abcdefghij"
+	var ranges []string
+	cfg := newTestConfig(t, func(req *http.Request) (*http.Response, error) {
+		header := http.Header{"Etag": {`"v1"`}, "Content-Type": {"application/pdf"}}
+		rangeHeader := req.Header.Get("Range")
+		ranges = append(ranges, rangeHeader+" "+req.Header.Get("If-Range"))
+		if rangeHeader == "" {
+			return &http.Response{StatusCode: 200, Header: header, ContentLength: int64(len(content)),
+				Body: io.NopCloser(brokenReader{strings.NewReader(content[:8])})}, nil
+		}
+		var start int
+		fmt.Sscanf(rangeHeader, "bytes=%d-", &start)
+		header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+		return &http.Response{StatusCode: http.StatusPartialContent, Header: header,
+			Body: io.NopCloser(strings.NewReader(content[start:]))}, nil
+	})
+	var body io.ReadCloser
+	cfg.ResponseBodyInto = &body
+
+	if err := cfg.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(body)
+	body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != content {
+		t.Fatalf("expected %q, got %q", content, data)
+	}
+	expected := []string{" ", `bytes=8- "v1"`}
+	if strings.Join(ranges, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected requests with ranges %q, got %q", expected, ranges)
+	}
+}
+
+func TestExecuteStreamFailsOnMismatchedRange(t *testing.T) {
+	cfg := newTestConfig(t, func(req *http.Request) (*http.Response, error) {
+		if req.Header.Get("Range") == "" {
+			return &http.Response{StatusCode: 200, Header: http.Header{}, ContentLength: 20,
+				Body: io.NopCloser(brokenReader{strings.NewReader("01234")})}, nil
+		}
+		// The server ignores the range and starts over.
+		return &http.Response{StatusCode: 200, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("0123456789"))}, nil
+	})
+	var body io.ReadCloser
+	cfg.ResponseBodyInto = &body
+	if err := cfg.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	if _, err := io.ReadAll(body); err == nil || !strings.Contains(err.Error(), "connection reset by peer") {
+		t.Fatalf("expected the read error to be returned, got %v", err)
+	}
+}
+
+func TestParseContentRange(t *testing.T) {
+	tests := map[string]struct {
+		start, total int64
+		ok           bool
+	}{
+		"bytes 100-999/1000": {100, 1000, true},
+		"bytes 0-9/*":        {0, -1, true},
+		"bytes */1000":       {0, 0, false},
+		"items 0-9/10":       {0, 0, false},
+	}
+	for header, expected := range tests {
+		start, total, ok := parseContentRange(header)
+		if start != expected.start || total != expected.total || ok != expected.ok {
+			t.Errorf("parseContentRange(%q) = %d, %d, %v", header, start, total, ok)
+		}
+	}
+}
+
+type uploadParams struct {
+	File io.Reader `form:"file"`
+}
+
+func (r uploadParams) MarshalMultipartStream() (*apiform.Stream, error) {
+	return apiform.NewStream(r)
+}
+
+func TestExecuteStreamsMultipartBody(t *testing.T) {
+	var bodies []string
+	transport := func(req *http.Request) (*http.Response, error) {
+		if req.ContentLength <= 0 {
+			t.Errorf("expected the Content-Length to be known, got %d", req.ContentLength)
+		}
+		raw, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		bodies = append(bodies, string(raw))
+		if len(bodies) == 1 {
+			return newResponse(503, http.Header{"Retry-After": {"0"}}, ""), nil
+		}
+		return newResponse(200, nil, "{}"), nil
+	}
+	base, _ := url.Parse("https://api.increase.com/")
+	cfg, err := NewRequestConfig(context.Background(), http.MethodPost, "files", uploadParams{File: strings.NewReader("contents")}, nil, func(r *RequestConfig) error {
+		r.BaseURL = base
+		r.HTTPClient = &http.Client{Transport: roundTripFunc(transport)}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Buffer) != 0 {
+		t.Fatal("expected the body not to be buffered")
+	}
+	if err := cfg.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] || !strings.Contains(bodies[1], "contents") {
+		t.Fatalf("expected the retry to send the same body again, got %q", bodies)
+	}
+}
+
+func TestExecuteDoesNotLeakStreamWhenNotSent(t *testing.T) {
+	base, _ := url.Parse("https://api.increase.com/")
+	upload := func() error {
+		cfg, err := NewRequestConfig(context.Background(), http.MethodPost, "files", uploadParams{File: strings.NewReader("contents")}, nil, func(r *RequestConfig) error {
+			r.BaseURL = base
+			r.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				t.Fatal("expected no request to be sent")
+				return nil, nil
+			})}
+			r.Middlewares = append(r.Middlewares, func(req *http.Request, next middlewareNext) (*http.Response, error) {
+				return nil, errors.New("rejected")
+			})
+			r.MaxRetries = 0
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return cfg.Execute()
+	}
+
+	before := runtime.NumGoroutine()
+	for i := 0; i < 50; i++ {
+		if err := upload(); err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+	// Give goroutines that would exit a moment to do so.
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("expected no goroutines to be left behind, went from %d to %d", before, after)
+	}
+}
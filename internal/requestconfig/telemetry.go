@@ -0,0 +1,227 @@
+package requestconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/increase/increase-go/internal"
+	"github.com/increase/increase-go/internal/apierror"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// instrumentationName is the name of the tracer and meter the SDK reports to.
+const instrumentationName = "github.com/increase/increase-go"
+
+// instrumented reports whether the request is traced or measured.
+func (cfg *RequestConfig) instrumented() bool {
+	return cfg.TracerProvider != nil || cfg.MeterProvider != nil
+}
+
+// operationName returns the name of the SDK method that is making the request,
+// such as "ACHTransferService.New", by looking for the first method of the root
+// package up the call stack. It returns "" if there is none, for example when
+// the request was made through [ExecuteNewRequest] directly.
+func operationName() string {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if name, ok := strings.CutPrefix(frame.Function, instrumentationName+".(*"); ok {
+			return strings.Replace(name, ").", ".", 1)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// resourceID returns the ID of the resource a path refers to, such as
+// "account_in71c4amph0vgo2qllky" for "/accounts/account_in71c4amph0vgo2qllky/close",
+// or "" if there is none.
+func resourceID(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 0 && segments[0] == "simulations" {
+		segments = segments[1:]
+	}
+	// IDs are always prefixed with their type, unlike fixed paths such as
+	// "/groups/current".
+	if len(segments) >= 2 && strings.Contains(segments[1], "_") {
+		return segments[1]
+	}
+	return ""
+}
+
+// instrument starts a span for the whole operation, and wraps the handler so
+// that every attempt gets a child span. The returned function ends the span and
+// records the metrics once the operation's outcome is known.
+func (cfg *RequestConfig) instrument(handler middlewareNext) (middlewareNext, func(error)) {
+	name := cfg.Operation
+	if name == "" {
+		name = cfg.Request.Method
+	}
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", cfg.Request.Method),
+		attribute.String("server.address", cfg.Request.URL.Hostname()),
+	}
+	if cfg.Operation != "" {
+		attrs = append(attrs, attribute.String("increase.operation", cfg.Operation))
+	}
+
+	tracerProvider := cfg.TracerProvider
+	if tracerProvider == nil {
+		tracerProvider = noop.NewTracerProvider()
+	}
+	tracer := tracerProvider.Tracer(instrumentationName, trace.WithInstrumentationVersion(internal.PackageVersion))
+	spanAttrs := append([]attribute.KeyValue{attribute.String("url.path", cfg.Request.URL.Path)}, attrs...)
+	if id := resourceID(cfg.Request.URL.Path); id != "" {
+		spanAttrs = append(spanAttrs, attribute.String("increase.resource_id", id))
+	}
+	if key := cfg.Request.Header.Get("Idempotency-Key"); key != "" {
+		spanAttrs = append(spanAttrs, attribute.String("increase.idempotency_key", key))
+	}
+	ctx, span := tracer.Start(cfg.Request.Context(), name, trace.WithSpanKind(trace.SpanKindInternal), trace.WithAttributes(spanAttrs...))
+	cfg.Context = ctx
+	cfg.Request = cfg.Request.WithContext(ctx)
+
+	attempts := 0
+	statusCode := 0
+	handler = func(next middlewareNext) middlewareNext {
+		return func(req *http.Request) (*http.Response, error) {
+			attempts++
+			ctx, span := tracer.Start(req.Context(), req.Method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+				attribute.String("http.request.method", req.Method),
+				attribute.String("url.full", redactURL(req.URL)),
+				attribute.String("server.address", req.URL.Hostname()),
+				attribute.Int("http.request.resend_count", attempts-1),
+			))
+			defer span.End()
+			req = req.WithContext(ctx)
+			otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			res, err := next(req)
+			if err != nil {
+				recordError(span, err, req.URL)
+				return res, err
+			}
+			statusCode = res.StatusCode
+			span.SetAttributes(attribute.Int("http.response.status_code", res.StatusCode))
+			if res.StatusCode >= 400 {
+				span.SetStatus(codes.Error, http.StatusText(res.StatusCode))
+			}
+			return res, err
+		}
+	}(handler)
+
+	start := time.Now()
+	return handler, func(err error) {
+		defer span.End()
+		retries := attempts - 1
+		if retries < 0 {
+			retries = 0
+		}
+		if statusCode != 0 {
+			attrs = append(attrs, attribute.Int("http.response.status_code", statusCode))
+		}
+		span.SetAttributes(attribute.Int("increase.retry_count", retries))
+		if err != nil {
+			errorType := errorType(err)
+			attrs = append(attrs, attribute.String("error.type", errorType))
+			span.SetAttributes(attribute.String("error.type", errorType))
+			var apiErr *apierror.Error
+			if errors.As(err, &apiErr) {
+				span.SetAttributes(attribute.String("increase.error.type", string(apiErr.Type)))
+			}
+			recordError(span, err, cfg.Request.URL)
+		}
+
+		if cfg.MeterProvider == nil {
+			return
+		}
+		meter := cfg.MeterProvider.Meter(instrumentationName, metric.WithInstrumentationVersion(internal.PackageVersion))
+		set := metric.WithAttributes(attrs...)
+		if duration, err := meter.Float64Histogram(
+			"increase.client.operation.duration",
+			metric.WithUnit("s"),
+			metric.WithDescription("The duration of SDK operations, including all of their attempts and retry delays."),
+		); err == nil {
+			duration.Record(context.WithoutCancel(ctx), time.Since(start).Seconds(), set)
+		}
+		if retryCount, err := meter.Int64Histogram(
+			"increase.client.operation.retries",
+			metric.WithUnit("{retry}"),
+			metric.WithDescription("The number of retries made by SDK operations."),
+		); err == nil {
+			retryCount.Record(context.WithoutCancel(ctx), int64(retries), set)
+		}
+	}
+}
+
+// redactURL returns the URL without its user info, query and fragment. The
+// query of a presigned URL, such as the download URL of a file, is a
+// credential, which must not reach the tracing backend.
+func redactURL(u *url.URL) string {
+	redacted := *u
+	redacted.User = nil
+	redacted.RawQuery = ""
+	redacted.ForceQuery = false
+	redacted.Fragment = ""
+	redacted.RawFragment = ""
+	return redacted.String()
+}
+
+// redactError returns the message of err, with the request URL in it redacted
+// as in [redactURL].
+func redactError(err error, u *url.URL) string {
+	message := err.Error()
+	if full, redacted := u.String(), redactURL(u); full != redacted {
+		message = strings.ReplaceAll(message, full, redacted)
+	}
+	if u.RawQuery != "" {
+		message = strings.ReplaceAll(message, "?"+u.RawQuery, "")
+	}
+	return message
+}
+
+// recordError records err on the span, with the request URL redacted from its
+// message.
+func recordError(span trace.Span, err error, u *url.URL) {
+	message := redactError(err, u)
+	span.AddEvent("exception", trace.WithAttributes(
+		attribute.String("exception.type", fmt.Sprintf("%T", err)),
+		attribute.String("exception.message", message),
+	))
+	span.SetStatus(codes.Error, message)
+}
+
+// errorType classifies an error for the "error.type" attribute: the type of an
+// API error, such as "invalid_parameters_error", or the Go type otherwise.
+func errorType(err error) string {
+	var apiErr *apierror.Error
+	switch {
+	case errors.As(err, &apiErr):
+		if apiErr.Type != "" {
+			return string(apiErr.Type)
+		}
+		return strconv.Itoa(apiErr.StatusCode)
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, apierror.ErrTimeout):
+		return "timeout"
+	case errors.Is(err, apierror.ErrConnection):
+		return "connection"
+	}
+	return fmt.Sprintf("%T", err)
+}
@@ -0,0 +1,147 @@
+package requestconfig
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestResourceID(t *testing.T) {
+	tests := map[string]string{
+		"/accounts":                                          "",
+		"/accounts/account_in71c4amph0vgo2qllky":             "account_in71c4amph0vgo2qllky",
+		"/accounts/account_in71c4amph0vgo2qllky/close":       "account_in71c4amph0vgo2qllky",
+		"/simulations/ach_transfers/ach_transfer_123/return": "ach_transfer_123",
+		"/simulations/card_authorizations":                   "",
+		"/groups/current":                                    "",
+	}
+	for path, expected := range tests {
+		if id := resourceID(path); id != expected {
+			t.Errorf("resourceID(%q) = %q, expected %q", path, id, expected)
+		}
+	}
+}
+
+func TestExecuteTracesOperationAndAttempts(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	attempts := 0
+	cfg := newTestConfig(t, func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if req.Header.Get("Traceparent") != "" {
+			t.Error("expected no trace context to be injected without a global propagator")
+		}
+		if attempts == 1 {
+			return newResponse(http.StatusInternalServerError, nil, ""), nil
+		}
+		return newResponse(http.StatusNotFound, http.Header{"Content-Type": {"application/json"}},
+			`{"type":"object_not_found_error","title":"Not found","status":404}`), nil
+	}, func(r *RequestConfig) error {
+		r.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+		r.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+		r.RetryPolicy = &ConstantRetryPolicy{}
+		return nil
+	})
+	cfg.Operation = "AccountService.Get"
+	cfg.Request.URL, _ = url.Parse("accounts/account_in71c4amph0vgo2qllky")
+
+	if err := cfg.Execute(); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 3 {
+		t.Fatalf("expected 2 attempt spans and 1 operation span, got %d", len(ended))
+	}
+	operation := ended[2]
+	if operation.Name() != "AccountService.Get" {
+		t.Errorf("expected the operation span to be named after the method, got %q", operation.Name())
+	}
+	if operation.Status().Code != codes.Error {
+		t.Errorf("expected the operation span to have an error status, got %v", operation.Status())
+	}
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, attr := range operation.Attributes() {
+		attrs[attr.Key] = attr.Value
+	}
+	if id := attrs["increase.resource_id"].AsString(); id != "account_in71c4amph0vgo2qllky" {
+		t.Errorf("expected the resource ID attribute, got %q", id)
+	}
+	if errorType := attrs["increase.error.type"].AsString(); errorType != "object_not_found_error" {
+		t.Errorf("expected the API error type attribute, got %q", errorType)
+	}
+	if retries := attrs["increase.retry_count"].AsInt64(); retries != 1 {
+		t.Errorf("expected 1 retry, got %d", retries)
+	}
+	for i, attempt := range ended[:2] {
+		if attempt.Parent().SpanID() != operation.SpanContext().SpanID() {
+			t.Errorf("expected attempt %d to be a child of the operation span", i)
+		}
+	}
+
+	var metrics metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &metrics); err != nil {
+		t.Fatal(err)
+	}
+	names := map[string]bool{}
+	for _, scope := range metrics.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			names[m.Name] = true
+		}
+	}
+	for _, name := range []string{"increase.client.operation.duration", "increase.client.operation.retries"} {
+		if !names[name] {
+			t.Errorf("expected the %s histogram to be recorded, got %v", name, names)
+		}
+	}
+}
+
+func TestExecuteTracesRedactedURL(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	cfg := newTestConfig(t, func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New(`Get "` + req.URL.String() + `": connection reset`)
+	}, func(r *RequestConfig) error {
+		r.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+		r.MaxRetries = 0
+		return nil
+	})
+	cfg.Request.URL, _ = url.Parse("https://files.increase.com/file_123?X-Amz-Signature=secret")
+
+	if err := cfg.Execute(); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if len(spans.Ended()) != 2 {
+		t.Fatalf("expected an attempt span and an operation span, got %d", len(spans.Ended()))
+	}
+	for _, span := range spans.Ended() {
+		for _, attr := range span.Attributes() {
+			if strings.Contains(attr.Value.Emit(), "secret") {
+				t.Errorf("expected the %s attribute of span %q to be redacted, got %q", attr.Key, span.Name(), attr.Value.Emit())
+			}
+			if attr.Key == "url.full" && attr.Value.AsString() != "https://files.increase.com/file_123" {
+				t.Errorf("expected the URL without its query, got %q", attr.Value.AsString())
+			}
+		}
+		for _, event := range span.Events() {
+			for _, attr := range event.Attributes {
+				if strings.Contains(attr.Value.Emit(), "secret") {
+					t.Errorf("expected the %s of span %q to be redacted, got %q", attr.Key, span.Name(), attr.Value.Emit())
+				}
+			}
+		}
+		if strings.Contains(span.Status().Description, "secret") {
+			t.Errorf("expected the status of span %q to be redacted, got %q", span.Name(), span.Status().Description)
+		}
+	}
+}
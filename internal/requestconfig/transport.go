@@ -0,0 +1,97 @@
+package requestconfig
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+// TransportConfig tunes the connections the client keeps to the API. A zero
+// field means no limit or no timeout, as for [http.Transport], so start from
+// [DefaultTransportConfig] rather than from the zero value.
+type TransportConfig struct {
+	// The maximum number of idle connections kept open, across all hosts.
+	MaxIdleConns int
+	// The maximum number of idle connections kept open to each host. Bursts of
+	// requests need this to be high enough that connections are reused rather
+	// than opened and closed again.
+	MaxIdleConnsPerHost int
+	// The maximum number of connections to each host, idle or not. Requests
+	// beyond it wait for a connection.
+	MaxConnsPerHost int
+	// How long an idle connection is kept open.
+	IdleConnTimeout time.Duration
+	// How long establishing a TCP connection may take.
+	DialTimeout time.Duration
+	// How often TCP keep-alive probes are sent.
+	KeepAlive time.Duration
+	// How long the TLS handshake may take.
+	TLSHandshakeTimeout time.Duration
+	// How long to wait for the response headers once the request is sent.
+	ResponseHeaderTimeout time.Duration
+	// After how long without any frame received an HTTP/2 connection is checked
+	// with a ping, so that broken connections are found before they are used.
+	HTTP2ReadIdleTimeout time.Duration
+	// How long to wait for the answer to that ping before closing the
+	// connection.
+	HTTP2PingTimeout time.Duration
+	// Use HTTP/1.1 only.
+	DisableHTTP2 bool
+}
+
+// DefaultTransportConfig returns the settings of the client's default
+// transport.
+func DefaultTransportConfig() TransportConfig {
+	return TransportConfig{
+		MaxIdleConns:         100,
+		MaxIdleConnsPerHost:  64,
+		IdleConnTimeout:      90 * time.Second,
+		DialTimeout:          10 * time.Second,
+		KeepAlive:            30 * time.Second,
+		TLSHandshakeTimeout:  10 * time.Second,
+		HTTP2ReadIdleTimeout: 30 * time.Second,
+		HTTP2PingTimeout:     15 * time.Second,
+	}
+}
+
+// NewTransport returns a transport with the given settings. Proxies are taken
+// from the environment, as for [http.DefaultTransport].
+func NewTransport(c TransportConfig) (*http.Transport, error) {
+	dialer := &net.Dialer{Timeout: c.DialTimeout, KeepAlive: c.KeepAlive}
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     !c.DisableHTTP2,
+		MaxIdleConns:          c.MaxIdleConns,
+		MaxIdleConnsPerHost:   c.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       c.MaxConnsPerHost,
+		IdleConnTimeout:       c.IdleConnTimeout,
+		TLSHandshakeTimeout:   c.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: c.ResponseHeaderTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+	if c.DisableHTTP2 {
+		return transport, nil
+	}
+	h2, err := http2.ConfigureTransports(transport)
+	if err != nil {
+		return nil, err
+	}
+	h2.ReadIdleTimeout = c.HTTP2ReadIdleTimeout
+	h2.PingTimeout = c.HTTP2PingTimeout
+	return transport, nil
+}
+
+// defaultHTTPClient is used by every client not given its own, so that they
+// share one pool of connections, separate from [http.DefaultClient].
+var defaultHTTPClient = sync.OnceValue(func() *http.Client {
+	transport, err := NewTransport(DefaultTransportConfig())
+	if err != nil {
+		// The default settings are valid, so this does not happen.
+		panic(err)
+	}
+	return &http.Client{Transport: transport}
+})
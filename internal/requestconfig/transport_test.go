@@ -0,0 +1,46 @@
+package requestconfig
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewTransport(t *testing.T) {
+	config := DefaultTransportConfig()
+	config.MaxConnsPerHost = 10
+	transport, err := NewTransport(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transport.MaxIdleConnsPerHost != 64 || transport.MaxConnsPerHost != 10 || transport.TLSHandshakeTimeout != 10*time.Second {
+		t.Errorf("expected the settings to be applied, got %+v", transport)
+	}
+	if _, ok := transport.TLSNextProto["h2"]; !ok {
+		t.Error("expected HTTP/2 to be configured")
+	}
+
+	config.DisableHTTP2 = true
+	transport, err = NewTransport(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := transport.TLSNextProto["h2"]; ok || transport.ForceAttemptHTTP2 {
+		t.Error("expected HTTP/2 to be disabled")
+	}
+}
+
+func TestDefaultHTTPClientIsShared(t *testing.T) {
+	first, err := NewRequestConfig(context.Background(), http.MethodGet, "accounts", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := NewRequestConfig(context.Background(), http.MethodGet, "cards", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.HTTPClient == http.DefaultClient || first.HTTPClient != second.HTTPClient {
+		t.Error("expected requests to share a default client other than http.DefaultClient")
+	}
+}
@@ -0,0 +1,113 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/increase/increase-go/internal/requestconfig"
+	"github.com/increase/increase-go/option"
+)
+
+// Checkpoint is the position of a [PageAutoPager] in its list. It can be
+// marshaled to JSON and stored, to resume the iteration later, from another
+// process, with the ResumeAutoPager method of the service.
+type Checkpoint struct {
+	// The path of the list, such as "transactions".
+	Path string `json:"path"`
+	// The query params of the list, URL-encoded, without the cursor.
+	Query string `json:"query"`
+	// The cursor of the current page, or empty for the first page.
+	Cursor string `json:"cursor,omitempty"`
+	// The number of items of the current page already returned by Next.
+	Index int `json:"index"`
+	// Whether the iteration is over.
+	Done bool `json:"done,omitempty"`
+}
+
+// Checkpoint returns the position of the pager: resuming from it returns the
+// items after the current one. If a page failed to load, it is the position of
+// that page, so that resuming tries it again.
+func (r *PageAutoPager[T]) Checkpoint() Checkpoint {
+	if r.page == nil || r.page.cfg == nil {
+		if r.err != nil {
+			return r.start
+		}
+		return Checkpoint{Done: true}
+	}
+	checkpoint := checkpointOf(r.page.cfg)
+	checkpoint.Index = r.idx
+	checkpoint.Done = len(r.page.Data) == 0 || (r.page.NextCursor == "" && r.idx >= len(r.page.Data))
+	return checkpoint
+}
+
+// checkpointOf returns the position of the first item of the page requested
+// with cfg.
+func checkpointOf(cfg *requestconfig.RequestConfig) Checkpoint {
+	req := cfg.Request
+	path := req.URL.Path
+	if base := cfg.BaseURL; base != nil && req.URL.IsAbs() {
+		path = strings.TrimPrefix(path, base.Path)
+	}
+	query := req.URL.Query()
+	cursor := query.Get("cursor")
+	query.Del("cursor")
+	return Checkpoint{
+		Path:   strings.TrimPrefix(path, "/"),
+		Query:  query.Encode(),
+		Cursor: cursor,
+	}
+}
+
+// ListPageAutoPager returns a pager over the list fetched by list, which
+// remembers where it started, for its checkpoint if the first page fails.
+func ListPageAutoPager[P any, T any](ctx context.Context, list func(context.Context, P, ...option.RequestOption) (*Page[T], error), query P, opts ...option.RequestOption) *PageAutoPager[T] {
+	var cfg *requestconfig.RequestConfig
+	opts = append(opts[:len(opts):len(opts)], func(r *requestconfig.RequestConfig) error {
+		cfg = r
+		return nil
+	})
+	pager := NewPageAutoPager(list(ctx, query, opts...))
+	if cfg != nil {
+		pager.start = checkpointOf(cfg)
+	}
+	return pager
+}
+
+// ResumePageAutoPager returns a pager for the list at path, which continues
+// from the checkpoint. It fetches the page of the checkpoint again, and skips
+// the items of it that were already returned.
+func ResumePageAutoPager[T any](ctx context.Context, path string, checkpoint Checkpoint, opts ...option.RequestOption) *PageAutoPager[T] {
+	if checkpoint.Done {
+		return NewPageAutoPager[T](nil, nil)
+	}
+	if checkpoint.Path != path {
+		return NewPageAutoPager[T](nil, fmt.Errorf("checkpoint of %q cannot resume the list of %q", checkpoint.Path, path))
+	}
+	query, err := url.ParseQuery(checkpoint.Query)
+	if err != nil {
+		return NewPageAutoPager[T](nil, fmt.Errorf("invalid checkpoint query: %w", err))
+	}
+	if checkpoint.Cursor != "" {
+		query.Set("cursor", checkpoint.Cursor)
+	}
+
+	var raw *http.Response
+	var res *Page[T]
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path+"?"+query.Encode(), nil, &res, opts...)
+	if err == nil {
+		err = cfg.Execute()
+	}
+	if err != nil {
+		pager := NewPageAutoPager[T](nil, err)
+		pager.start = checkpoint
+		return pager
+	}
+	res.SetPageConfig(cfg, raw)
+	pager := NewPageAutoPager(res, nil)
+	pager.idx = min(max(checkpoint.Index, 0), len(res.Data))
+	return pager
+}
@@ -59,11 +59,16 @@ func (r *Page[T]) SetPageConfig(cfg *requestconfig.RequestConfig, res *http.Resp
 }
 
 type PageAutoPager[T any] struct {
-	page *Page[T]
-	cur  T
-	idx  int
-	run  int
-	err  error
+	page     *Page[T]
+	cur      T
+	idx      int
+	run      int
+	err      error
+	done     bool
+	prefetch *prefetcher[T]
+	// The position the pager started from, for its checkpoint if the first
+	// page failed.
+	start Checkpoint
 }
 
 func NewPageAutoPager[T any](page *Page[T], err error) *PageAutoPager[T] {
@@ -74,13 +79,22 @@ func NewPageAutoPager[T any](page *Page[T], err error) *PageAutoPager[T] {
 }
 
 func (r *PageAutoPager[T]) Next() bool {
-	if r.page == nil || len(r.page.Data) == 0 {
+	if r.done || r.page == nil || len(r.page.Data) == 0 {
+		r.Close()
 		return false
 	}
+	r.startPrefetch()
 	if r.idx >= len(r.page.Data) {
+		page, err := r.nextPage()
+		if err != nil {
+			r.err = err
+			r.Close()
+			return false
+		}
 		r.idx = 0
-		r.page, r.err = r.page.GetNextPage()
-		if r.err != nil || r.page == nil || len(r.page.Data) == 0 {
+		r.page = page
+		if r.page == nil || len(r.page.Data) == 0 {
+			r.Close()
 			return false
 		}
 	}
@@ -0,0 +1,74 @@
+//go:build go1.23
+
+package shared
+
+import (
+	"context"
+	"iter"
+)
+
+// Pages returns this page and each of the following ones, fetched as the
+// sequence is iterated, or ahead of it with [option.WithPrefetch]. It ends after
+// the last page, or with the error of the page that failed to load.
+func (r *Page[T]) Pages() iter.Seq2[*Page[T], error] {
+	return func(yield func(*Page[T], error) bool) {
+		var prefetch *prefetcher[T]
+		if n := r.prefetches(); n > 0 {
+			prefetch = newPrefetcher(r, n)
+			defer prefetch.stop()
+		}
+		for page := r; page != nil; {
+			if !yield(page, nil) || len(page.Data) == 0 {
+				return
+			}
+			var err error
+			if prefetch != nil {
+				page, err = prefetch.next()
+			} else {
+				page, err = page.GetNextPage()
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
+
+// All returns the items of the list whose first page is loaded by first, and
+// then of the following pages. Nothing is fetched until the sequence is
+// iterated. It ends after limit items, unless limit is negative, or with the
+// error that stopped it, such as ctx being canceled.
+func All[T any](ctx context.Context, limit int64, first func() (*Page[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		if limit == 0 {
+			return
+		}
+		page, err := first()
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		n := int64(0)
+		for page, err := range page.Pages() {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.Data {
+				if err := ctx.Err(); err != nil {
+					yield(zero, err)
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+				n++
+				if limit > 0 && n >= limit {
+					return
+				}
+			}
+		}
+	}
+}
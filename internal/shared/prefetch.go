@@ -0,0 +1,141 @@
+package shared
+
+import (
+	"context"
+	"time"
+)
+
+type pageResult[T any] struct {
+	page *Page[T]
+	err  error
+}
+
+// prefetchIdle is how long the prefetcher waits for the caller to take a page
+// before it stops, so that an iteration abandoned without Close doesn't keep it
+// running. It starts again if the caller comes back.
+var prefetchIdle = 30 * time.Second
+
+// prefetcher fetches the pages that follow a page in the background, up to
+// lookahead pages ahead of the caller.
+type prefetcher[T any] struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	lookahead int
+	idle      time.Duration
+	// The page handed over last, after which fetching starts again.
+	last *Page[T]
+	run  *prefetchRun[T]
+}
+
+// prefetchRun is a goroutine fetching pages, until the last one, an error, or
+// the caller not taking them.
+type prefetchRun[T any] struct {
+	results chan pageResult[T]
+	// Set before results is closed if the caller stopped taking pages.
+	idle bool
+}
+
+func newPrefetcher[T any](page *Page[T], lookahead int) *prefetcher[T] {
+	ctx, cancel := context.WithCancel(page.cfg.Context)
+	p := &prefetcher[T]{ctx: ctx, cancel: cancel, lookahead: lookahead, idle: prefetchIdle, last: page}
+	p.start()
+	return p
+}
+
+func (p *prefetcher[T]) start() {
+	// The page waiting to be handed over counts towards the lookahead.
+	run := &prefetchRun[T]{results: make(chan pageResult[T], p.lookahead-1)}
+	p.run = run
+	page := p.last
+	go func() {
+		defer close(run.results)
+		for page != nil && len(page.Data) > 0 {
+			next, err := page.nextPage(p.ctx)
+			idle := time.NewTimer(p.idle)
+			select {
+			case run.results <- pageResult[T]{next, err}:
+				idle.Stop()
+			case <-p.ctx.Done():
+				idle.Stop()
+				return
+			case <-idle.C:
+				run.idle = true
+				return
+			}
+			if err != nil {
+				return
+			}
+			page = next
+		}
+	}()
+}
+
+// next returns the page that follows the one returned before, or nil after the
+// last one.
+func (p *prefetcher[T]) next() (*Page[T], error) {
+	result, ok := <-p.run.results
+	if !ok && p.run.idle {
+		// The caller came back after the prefetcher stopped.
+		p.start()
+		result, ok = <-p.run.results
+	}
+	if !ok {
+		return nil, nil
+	}
+	if result.page != nil {
+		p.last = result.page
+	}
+	return result.page, result.err
+}
+
+// stop cancels the request in flight and drops the pages fetched ahead.
+func (p *prefetcher[T]) stop() {
+	p.cancel()
+}
+
+// nextPage fetches the next page with ctx, rather than the context of the list,
+// so that the prefetcher can cancel it. The page is then given the context of
+// the list, to fetch the pages after it.
+func (r *Page[T]) nextPage(ctx context.Context) (*Page[T], error) {
+	page := *r
+	page.cfg = r.cfg.Clone(ctx)
+	next, err := page.GetNextPage()
+	if err != nil || next == nil {
+		return next, err
+	}
+	next.cfg = next.cfg.Clone(r.cfg.Context)
+	return next, nil
+}
+
+// prefetches reports how many pages are to be fetched ahead of the caller.
+func (r *Page[T]) prefetches() int {
+	if r == nil || r.cfg == nil {
+		return 0
+	}
+	return r.cfg.Prefetch
+}
+
+func (r *PageAutoPager[T]) startPrefetch() {
+	if n := r.page.prefetches(); n > 0 && r.prefetch == nil {
+		r.prefetch = newPrefetcher(r.page, n)
+	}
+}
+
+// nextPage returns the page after the current one, from the prefetcher if
+// pages are fetched ahead.
+func (r *PageAutoPager[T]) nextPage() (*Page[T], error) {
+	if r.prefetch == nil {
+		return r.page.GetNextPage()
+	}
+	return r.prefetch.next()
+}
+
+// Close ends the iteration, and drops the pages fetched ahead, if any. Next
+// returns false afterwards. Close is called when Next returns false, so it is
+// only needed when leaving the iteration early.
+func (r *PageAutoPager[T]) Close() {
+	r.done = true
+	if r.prefetch != nil {
+		r.prefetch.stop()
+	}
+}
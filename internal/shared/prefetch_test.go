@@ -0,0 +1,119 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/increase/increase-go/internal/requestconfig"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+type item struct {
+	ID string `json:"id"`
+}
+
+// listItems returns a pager over total items, served ten to a page, and the
+// number of requests made.
+func listItems(t *testing.T, total int, prefetch int) (*PageAutoPager[item], *atomic.Int32) {
+	t.Helper()
+	requests := &atomic.Int32{}
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests.Add(1)
+		start, _ := strconv.Atoi(req.URL.Query().Get("cursor"))
+		end := min(start+10, total)
+		var data []string
+		for i := start; i < end; i++ {
+			data = append(data, fmt.Sprintf(`{"id":"item_%d"}`, i))
+		}
+		next := "null"
+		if end < total {
+			next = fmt.Sprintf(`"%d"`, end)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(fmt.Sprintf(`{"data":[%s],"next_cursor":%s}`, strings.Join(data, ","), next))),
+		}, nil
+	})
+
+	base, _ := url.Parse("https://api.increase.com/")
+	var raw *http.Response
+	var page *Page[item]
+	cfg, err := requestconfig.NewRequestConfig(context.Background(), http.MethodGet, "items", nil, &page, func(r *requestconfig.RequestConfig) error {
+		r.BaseURL = base
+		r.HTTPClient = &http.Client{Transport: transport}
+		r.Prefetch = prefetch
+		r.ResponseInto = &raw
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	page.SetPageConfig(cfg, raw)
+	return NewPageAutoPager(page, nil), requests
+}
+
+func TestPrefetchStopsWhenAbandoned(t *testing.T) {
+	defer func(idle time.Duration) { prefetchIdle = idle }(prefetchIdle)
+	prefetchIdle = 20 * time.Millisecond
+
+	before := runtime.NumGoroutine()
+	for i := 0; i < 20; i++ {
+		pager, _ := listItems(t, 1000, 3)
+		if !pager.Next() {
+			t.Fatal("expected an item")
+		}
+		// The pager is abandoned without calling Close.
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("expected the prefetchers to stop, went from %d to %d goroutines", before, after)
+	}
+}
+
+func TestPrefetchResumesAfterIdle(t *testing.T) {
+	defer func(idle time.Duration) { prefetchIdle = idle }(prefetchIdle)
+	prefetchIdle = 20 * time.Millisecond
+
+	pager, requests := listItems(t, 100, 3)
+	n := 0
+	for pager.Next() {
+		if id := pager.Current().ID; id != fmt.Sprintf("item_%d", n) {
+			t.Fatalf("expected item_%d, got %s", n, id)
+		}
+		n++
+		if n%25 == 0 {
+			// Long enough for the prefetcher to stop.
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+	if err := pager.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 100 {
+		t.Fatalf("expected 100 items, got %d", n)
+	}
+	// Pages fetched ahead and dropped when the prefetcher stopped are fetched
+	// again, at most once per pause.
+	if got := requests.Load(); got < 10 || got > 13 {
+		t.Fatalf("expected between 10 and 13 requests, got %d", got)
+	}
+}
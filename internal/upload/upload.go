@@ -0,0 +1,203 @@
+// Package upload inspects files before they are uploaded, to catch the ones
+// Increase would reject for their purpose without sending them.
+package upload
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// HeadSize is how many bytes from the start of a file are used to find its
+// content type and resolution.
+const HeadSize = 64 << 10
+
+// Info describes a file, as found from its contents.
+type Info struct {
+	ContentType string
+	// The size of the file in bytes, or -1 if unknown.
+	Size int64
+	// The dimensions of an image in pixels, or zero if the file is not an image
+	// or they could not be found.
+	Width  int
+	Height int
+	// The resolution recorded in an image, in dots per inch, or zero if none
+	// was found.
+	DPI float64
+}
+
+// Inspect describes a file, and seeks back to its start. The content type is
+// sniffed from the first HeadSize bytes and, if they are not recognized, found
+// from the extension of the filename. The dimensions of an image are read from
+// its header, however far into the file it is.
+func Inspect(r io.ReadSeeker, size int64, filename string) (Info, error) {
+	head := make([]byte, HeadSize)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return Info{}, err
+	}
+	head = head[:n]
+
+	info := Info{ContentType: http.DetectContentType(head), Size: size}
+	if generic(info.ContentType) {
+		if byExtension := mime.TypeByExtension(path.Ext(filename)); byExtension != "" {
+			info.ContentType = byExtension
+		}
+	}
+	if strings.HasPrefix(info.ContentType, "image/") {
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			return Info{}, err
+		}
+		if config, _, err := image.DecodeConfig(r); err == nil {
+			info.Width, info.Height = config.Width, config.Height
+		}
+	}
+	switch info.ContentType {
+	case "image/png":
+		info.DPI = pngDPI(head)
+	case "image/jpeg":
+		info.DPI = jpegDPI(head)
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return Info{}, err
+	}
+	return info, nil
+}
+
+func generic(contentType string) bool {
+	return contentType == "application/octet-stream" || strings.HasPrefix(contentType, "text/plain")
+}
+
+// pngDPI reads the resolution from the pHYs chunk of a PNG image.
+func pngDPI(head []byte) float64 {
+	const signature = "\x89PNG\r\n\x1a\n"
+	if !bytes.HasPrefix(head, []byte(signature)) {
+		return 0
+	}
+	for b := head[len(signature):]; len(b) >= 8; {
+		length := int(binary.BigEndian.Uint32(b[:4]))
+		kind := string(b[4:8])
+		if kind == "IDAT" || length < 0 || len(b) < 12+length {
+			return 0
+		}
+		if kind == "pHYs" && length == 9 {
+			data := b[8 : 8+length]
+			// Unit 1 is pixels per meter; otherwise only the aspect ratio is known.
+			if data[8] == 1 {
+				return float64(binary.BigEndian.Uint32(data[:4])) * 0.0254
+			}
+			return 0
+		}
+		b = b[12+length:]
+	}
+	return 0
+}
+
+// jpegDPI reads the resolution from the JFIF segment of a JPEG image.
+func jpegDPI(head []byte) float64 {
+	if len(head) < 18 || head[0] != 0xff || head[1] != 0xd8 || head[2] != 0xff || head[3] != 0xe0 || string(head[6:11]) != "JFIF\x00" {
+		return 0
+	}
+	density := float64(binary.BigEndian.Uint16(head[14:16]))
+	switch head[13] {
+	case 1:
+		return density
+	case 2:
+		return density * 2.54
+	}
+	return 0
+}
+
+// constraint is what Increase expects of a file with a given purpose. Only the
+// constraints documented by the API, in the descriptions of the purposes, are
+// required; the others are recommendations.
+type constraint struct {
+	documented   bool
+	contentTypes []string
+	// Exact dimensions in pixels.
+	width, height int
+	// Minimum dimensions in pixels, whichever way the image is oriented.
+	minLongSide, minShortSide int
+	// Minimum resolution, checked only if the image records one.
+	minDPI  float64
+	maxSize int64
+}
+
+var (
+	images        = []string{"image/jpeg", "image/png", "image/gif"}
+	imagesAndPDFs = []string{"image/jpeg", "image/png", "image/gif", "application/pdf"}
+	// Recommendations for check images to be read reliably, which are not
+	// documented API limits.
+	checkImage  = constraint{contentTypes: []string{"image/jpeg", "image/png"}, minLongSide: 900, minShortSide: 375, minDPI: 150, maxSize: 10 << 20}
+	constraints = map[string]constraint{
+		"check_image_front":        checkImage,
+		"check_image_back":         checkImage,
+		"mailed_check_image":       {contentTypes: images},
+		"identity_document":        {contentTypes: imagesAndPDFs},
+		"form_ss_4":                {contentTypes: imagesAndPDFs},
+		"trust_formation_document": {contentTypes: imagesAndPDFs},
+		"digital_wallet_artwork":   pngOfSize(1536, 969),
+		"digital_wallet_app_icon":  pngOfSize(100, 100),
+		"physical_card_front":      pngOfSize(2100, 1340),
+		"physical_card_carrier":    pngOfSize(2550, 3300),
+	}
+)
+
+// pngOfSize is documented in the descriptions of the card and digital wallet
+// purposes, such as "This must be a 1536x969 pixel PNG".
+func pngOfSize(width, height int) constraint {
+	return constraint{documented: true, contentTypes: []string{"image/png"}, width: width, height: height}
+}
+
+// Check returns why the file is not valid for the purpose, or "" if it is.
+// Advisory reports whether the reason only breaks a recommendation, rather than
+// a documented requirement of the API. Dimensions and resolutions that could
+// not be found are not checked.
+func Check(purpose string, info Info) (reason string, advisory bool) {
+	if info.Size == 0 {
+		return "the file is empty", false
+	}
+	c, ok := constraints[purpose]
+	if !ok {
+		return "", false
+	}
+	advisory = !c.documented
+	if len(c.contentTypes) > 0 && !contains(c.contentTypes, info.ContentType) {
+		return fmt.Sprintf("the file is %s, but must be %s", info.ContentType, strings.Join(c.contentTypes, " or ")), advisory
+	}
+	if c.maxSize > 0 && info.Size > c.maxSize {
+		return fmt.Sprintf("the file is %d bytes, but must be at most %d bytes", info.Size, c.maxSize), advisory
+	}
+	if info.Width > 0 && info.Height > 0 {
+		if c.width > 0 && (info.Width != c.width || info.Height != c.height) {
+			return fmt.Sprintf("the image is %dx%d pixels, but must be %dx%d pixels", info.Width, info.Height, c.width, c.height), advisory
+		}
+		long, short := max(info.Width, info.Height), min(info.Width, info.Height)
+		if c.minLongSide > 0 && (long < c.minLongSide || short < c.minShortSide) {
+			return fmt.Sprintf("the image is %dx%d pixels, but must be at least %dx%d pixels", info.Width, info.Height, c.minLongSide, c.minShortSide), advisory
+		}
+	}
+	if c.minDPI > 0 && info.DPI > 0 && info.DPI < c.minDPI {
+		return fmt.Sprintf("the image is %.0f DPI, but must be at least %.0f DPI", info.DPI, c.minDPI), advisory
+	}
+	return "", false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
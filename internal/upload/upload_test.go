@@ -0,0 +1,152 @@
+package upload
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func encodePNG(t *testing.T, width, height int, pixelsPerMeter uint32) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	if pixelsPerMeter == 0 {
+		return buf.Bytes()
+	}
+	// Insert a pHYs chunk after the IHDR chunk, which ends at byte 33.
+	data := make([]byte, 9)
+	binary.BigEndian.PutUint32(data[0:], pixelsPerMeter)
+	binary.BigEndian.PutUint32(data[4:], pixelsPerMeter)
+	data[8] = 1
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(chunk, "pHYs"...)
+	chunk = append(chunk, data...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+	raw := buf.Bytes()
+	return append(append(append([]byte{}, raw[:33]...), chunk...), raw[33:]...)
+}
+
+func encodeJPEG(t *testing.T, width, height int, dpi uint16) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)), nil); err != nil {
+		t.Fatal(err)
+	}
+	// Insert a JFIF segment after the SOI marker.
+	segment := []byte{0xff, 0xe0, 0, 16, 'J', 'F', 'I', 'F', 0, 1, 1, 1}
+	segment = binary.BigEndian.AppendUint16(segment, dpi)
+	segment = binary.BigEndian.AppendUint16(segment, dpi)
+	segment = append(segment, 0, 0)
+	raw := buf.Bytes()
+	return append(append(append([]byte{}, raw[:2]...), segment...), raw[2:]...)
+}
+
+// withMetadata inserts an APP1 segment of the given size after the SOI marker of
+// a JPEG image, as EXIF or ICC metadata would be.
+func withMetadata(jpegImage []byte, size int) []byte {
+	var out []byte
+	out = append(out, jpegImage[:2]...)
+	for size > 0 {
+		n := min(size, 0xfff0)
+		segment := []byte{0xff, 0xe1}
+		segment = binary.BigEndian.AppendUint16(segment, uint16(n+2))
+		segment = append(segment, make([]byte, n)...)
+		out = append(out, segment...)
+		size -= n
+	}
+	return append(out, jpegImage[2:]...)
+}
+
+func inspect(t *testing.T, data []byte, filename string) Info {
+	t.Helper()
+	info, err := Inspect(bytes.NewReader(data), int64(len(data)), filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info
+}
+
+func TestInspect(t *testing.T) {
+	info := inspect(t, encodePNG(t, 1536, 969, 11811), "")
+	if info.ContentType != "image/png" || info.Width != 1536 || info.Height != 969 || int(info.DPI+0.5) != 300 {
+		t.Errorf("unexpected info for a PNG image: %+v", info)
+	}
+
+	info = inspect(t, encodeJPEG(t, 1200, 500, 200), "")
+	if info.ContentType != "image/jpeg" || info.Width != 1200 || info.Height != 500 || info.DPI != 200 {
+		t.Errorf("unexpected info for a JPEG image: %+v", info)
+	}
+
+	// The dimensions are found past HeadSize, after large metadata.
+	jpegImage := withMetadata(encodeJPEG(t, 1600, 800, 0), 73<<10)
+	info = inspect(t, jpegImage, "")
+	if info.ContentType != "image/jpeg" || info.Width != 1600 || info.Height != 800 {
+		t.Errorf("unexpected info for a JPEG image with metadata: %+v", info)
+	}
+
+	info = inspect(t, []byte("a,b\n1,2\n"), "export.csv")
+	if !strings.HasPrefix(info.ContentType, "text/csv") || info.Width != 0 {
+		t.Errorf("expected the content type to be found from the extension, got %+v", info)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := map[string]struct {
+		purpose  string
+		info     Info
+		reason   string
+		advisory bool
+	}{
+		"artwork": {
+			"digital_wallet_artwork", inspect(t, encodePNG(t, 1536, 969, 0), ""), "", false,
+		},
+		"artwork of the wrong size": {
+			"digital_wallet_artwork", inspect(t, encodePNG(t, 1500, 969, 0), ""), "the image is 1500x969 pixels, but must be 1536x969 pixels", false,
+		},
+		"artwork that is not a PNG": {
+			"digital_wallet_artwork", inspect(t, encodeJPEG(t, 1536, 969, 0), ""), "the file is image/jpeg, but must be image/png", false,
+		},
+		"artwork of unknown size": {
+			"digital_wallet_artwork", Info{ContentType: "image/png", Size: 100}, "", false,
+		},
+		"check image": {
+			"check_image_front", inspect(t, encodeJPEG(t, 1200, 500, 200), ""), "", false,
+		},
+		"portrait check image": {
+			"check_image_back", inspect(t, encodeJPEG(t, 500, 1200, 0), ""), "", false,
+		},
+		"small check image": {
+			"check_image_front", inspect(t, encodeJPEG(t, 600, 250, 0), ""), "the image is 600x250 pixels, but must be at least 900x375 pixels", true,
+		},
+		"low resolution check image": {
+			"check_image_front", inspect(t, encodePNG(t, 1200, 500, 2835), ""), "the image is 72 DPI, but must be at least 150 DPI", true,
+		},
+		"check image of unknown size": {
+			"check_image_front", Info{ContentType: "image/jpeg", Size: 100}, "", false,
+		},
+		"identity document": {
+			"identity_document", inspect(t, []byte("%PDF-1.4\n"), ""), "", false,
+		},
+		"empty": {
+			"other", Info{Size: 0}, "the file is empty", false,
+		},
+		"other": {
+			"other", inspect(t, []byte("anything"), ""), "", false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			reason, advisory := Check(test.purpose, test.info)
+			if reason != test.reason || advisory != test.advisory {
+				t.Errorf("expected (%q, %v), got (%q, %v)", test.reason, test.advisory, reason, advisory)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package increase
+
+import (
+	"context"
+
+	"github.com/increase/increase-go/internal/requestconfig"
+	"github.com/increase/increase-go/option"
+)
+
+// ReplayJournal sends every pending request recorded in the journal again, with
+// its original idempotency key, and records the responses. Call it on startup
+// to settle the requests that were in flight when the process stopped. The
+// client's options, followed by opts, supply settings such as the API key.
+func (r *Client) ReplayJournal(ctx context.Context, store option.JournalStore, opts ...option.RequestOption) error {
+	opts = append(r.Options[:], opts...)
+	return requestconfig.ReplayJournal(ctx, store, opts...)
+}
@@ -0,0 +1,413 @@
+//go:build go1.23
+
+package increase
+
+import (
+	"context"
+	"iter"
+
+	"github.com/increase/increase-go/internal/param"
+	"github.com/increase/increase-go/internal/shared"
+	"github.com/increase/increase-go/option"
+)
+
+// maxPageSize is the largest page the API returns.
+const maxPageSize = 100
+
+// totalLimit turns the Limit of list params into a cap on the total number of
+// items, returning -1 if there is none, and keeps the page size it is sent as
+// within the maximum.
+func totalLimit(limit *param.Field[int64]) int64 {
+	if !limit.Present || limit.Null {
+		return -1
+	}
+	total := limit.Value
+	if total > maxPageSize {
+		*limit = F[int64](maxPageSize)
+	}
+	return total
+}
+
+// All lists ACH Prenotifications, like
+// [ACHPrenotificationService.ListAutoPaging], as a sequence for range. Pages
+// are fetched as it is iterated, and the Limit of the query caps the total
+// number of items rather than the page size.
+func (r *ACHPrenotificationService) All(ctx context.Context, query ACHPrenotificationListParams, opts ...option.RequestOption) iter.Seq2[ACHPrenotification, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[ACHPrenotification], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists ACH Transfers, like [ACHTransferService.ListAutoPaging], as a
+// sequence for range. Pages are fetched as it is iterated, and the Limit of the
+// query caps the total number of items rather than the page size.
+func (r *ACHTransferService) All(ctx context.Context, query ACHTransferListParams, opts ...option.RequestOption) iter.Seq2[ACHTransfer, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[ACHTransfer], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Account Numbers, like [AccountNumberService.ListAutoPaging], as a
+// sequence for range. Pages are fetched as it is iterated, and the Limit of the
+// query caps the total number of items rather than the page size.
+func (r *AccountNumberService) All(ctx context.Context, query AccountNumberListParams, opts ...option.RequestOption) iter.Seq2[AccountNumber, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[AccountNumber], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Accounts, like [AccountService.ListAutoPaging], as a sequence for
+// range. Pages are fetched as it is iterated, and the Limit of the query caps
+// the total number of items rather than the page size.
+func (r *AccountService) All(ctx context.Context, query AccountListParams, opts ...option.RequestOption) iter.Seq2[Account, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[Account], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Account Statements, like [AccountStatementService.ListAutoPaging],
+// as a sequence for range. Pages are fetched as it is iterated, and the Limit
+// of the query caps the total number of items rather than the page size.
+func (r *AccountStatementService) All(ctx context.Context, query AccountStatementListParams, opts ...option.RequestOption) iter.Seq2[AccountStatement, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[AccountStatement], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Account Transfers, like [AccountTransferService.ListAutoPaging], as
+// a sequence for range. Pages are fetched as it is iterated, and the Limit of
+// the query caps the total number of items rather than the page size.
+func (r *AccountTransferService) All(ctx context.Context, query AccountTransferListParams, opts ...option.RequestOption) iter.Seq2[AccountTransfer, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[AccountTransfer], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Bookkeeping Accounts, like
+// [BookkeepingAccountService.ListAutoPaging], as a sequence for range. Pages
+// are fetched as it is iterated, and the Limit of the query caps the total
+// number of items rather than the page size.
+func (r *BookkeepingAccountService) All(ctx context.Context, query BookkeepingAccountListParams, opts ...option.RequestOption) iter.Seq2[BookkeepingAccount, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[BookkeepingAccount], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Bookkeeping Entries, like [BookkeepingEntryService.ListAutoPaging],
+// as a sequence for range. Pages are fetched as it is iterated, and the Limit
+// of the query caps the total number of items rather than the page size.
+func (r *BookkeepingEntryService) All(ctx context.Context, query BookkeepingEntryListParams, opts ...option.RequestOption) iter.Seq2[BookkeepingEntry, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[BookkeepingEntry], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Bookkeeping Entry Sets, like
+// [BookkeepingEntrySetService.ListAutoPaging], as a sequence for range. Pages
+// are fetched as it is iterated, and the Limit of the query caps the total
+// number of items rather than the page size.
+func (r *BookkeepingEntrySetService) All(ctx context.Context, query BookkeepingEntrySetListParams, opts ...option.RequestOption) iter.Seq2[BookkeepingEntrySet, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[BookkeepingEntrySet], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Card Disputes, like [CardDisputeService.ListAutoPaging], as a
+// sequence for range. Pages are fetched as it is iterated, and the Limit of the
+// query caps the total number of items rather than the page size.
+func (r *CardDisputeService) All(ctx context.Context, query CardDisputeListParams, opts ...option.RequestOption) iter.Seq2[CardDispute, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[CardDispute], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Card Payments, like [CardPaymentService.ListAutoPaging], as a
+// sequence for range. Pages are fetched as it is iterated, and the Limit of the
+// query caps the total number of items rather than the page size.
+func (r *CardPaymentService) All(ctx context.Context, query CardPaymentListParams, opts ...option.RequestOption) iter.Seq2[CardPayment, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[CardPayment], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Card Profiles, like [CardProfileService.ListAutoPaging], as a
+// sequence for range. Pages are fetched as it is iterated, and the Limit of the
+// query caps the total number of items rather than the page size.
+func (r *CardProfileService) All(ctx context.Context, query CardProfileListParams, opts ...option.RequestOption) iter.Seq2[CardProfile, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[CardProfile], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Card Purchase Supplements, like
+// [CardPurchaseSupplementService.ListAutoPaging], as a sequence for range.
+// Pages are fetched as it is iterated, and the Limit of the query caps the
+// total number of items rather than the page size.
+func (r *CardPurchaseSupplementService) All(ctx context.Context, query CardPurchaseSupplementListParams, opts ...option.RequestOption) iter.Seq2[CardPurchaseSupplement, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[CardPurchaseSupplement], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Cards, like [CardService.ListAutoPaging], as a sequence for range.
+// Pages are fetched as it is iterated, and the Limit of the query caps the
+// total number of items rather than the page size.
+func (r *CardService) All(ctx context.Context, query CardListParams, opts ...option.RequestOption) iter.Seq2[Card, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[Card], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Check Deposits, like [CheckDepositService.ListAutoPaging], as a
+// sequence for range. Pages are fetched as it is iterated, and the Limit of the
+// query caps the total number of items rather than the page size.
+func (r *CheckDepositService) All(ctx context.Context, query CheckDepositListParams, opts ...option.RequestOption) iter.Seq2[CheckDeposit, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[CheckDeposit], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Check Transfers, like [CheckTransferService.ListAutoPaging], as a
+// sequence for range. Pages are fetched as it is iterated, and the Limit of the
+// query caps the total number of items rather than the page size.
+func (r *CheckTransferService) All(ctx context.Context, query CheckTransferListParams, opts ...option.RequestOption) iter.Seq2[CheckTransfer, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[CheckTransfer], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Declined Transactions, like
+// [DeclinedTransactionService.ListAutoPaging], as a sequence for range. Pages
+// are fetched as it is iterated, and the Limit of the query caps the total
+// number of items rather than the page size.
+func (r *DeclinedTransactionService) All(ctx context.Context, query DeclinedTransactionListParams, opts ...option.RequestOption) iter.Seq2[DeclinedTransaction, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[DeclinedTransaction], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Digital Wallet Tokens, like
+// [DigitalWalletTokenService.ListAutoPaging], as a sequence for range. Pages
+// are fetched as it is iterated, and the Limit of the query caps the total
+// number of items rather than the page size.
+func (r *DigitalWalletTokenService) All(ctx context.Context, query DigitalWalletTokenListParams, opts ...option.RequestOption) iter.Seq2[DigitalWalletToken, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[DigitalWalletToken], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Documents, like [DocumentService.ListAutoPaging], as a sequence for
+// range. Pages are fetched as it is iterated, and the Limit of the query caps
+// the total number of items rather than the page size.
+func (r *DocumentService) All(ctx context.Context, query DocumentListParams, opts ...option.RequestOption) iter.Seq2[Document, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[Document], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Entities, like [EntityService.ListAutoPaging], as a sequence for
+// range. Pages are fetched as it is iterated, and the Limit of the query caps
+// the total number of items rather than the page size.
+func (r *EntityService) All(ctx context.Context, query EntityListParams, opts ...option.RequestOption) iter.Seq2[Entity, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[Entity], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Entity Supplemental Document Submissions, like
+// [EntitySupplementalDocumentService.ListAutoPaging], as a sequence for range.
+// Pages are fetched as it is iterated, and the Limit of the query caps the
+// total number of items rather than the page size.
+func (r *EntitySupplementalDocumentService) All(ctx context.Context, query EntitySupplementalDocumentListParams, opts ...option.RequestOption) iter.Seq2[SupplementalDocument, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[SupplementalDocument], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Events, like [EventService.ListAutoPaging], as a sequence for
+// range. Pages are fetched as it is iterated, and the Limit of the query caps
+// the total number of items rather than the page size.
+func (r *EventService) All(ctx context.Context, query EventListParams, opts ...option.RequestOption) iter.Seq2[Event, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[Event], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Event Subscriptions, like
+// [EventSubscriptionService.ListAutoPaging], as a sequence for range. Pages are
+// fetched as it is iterated, and the Limit of the query caps the total number
+// of items rather than the page size.
+func (r *EventSubscriptionService) All(ctx context.Context, query EventSubscriptionListParams, opts ...option.RequestOption) iter.Seq2[EventSubscription, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[EventSubscription], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Exports, like [ExportService.ListAutoPaging], as a sequence for
+// range. Pages are fetched as it is iterated, and the Limit of the query caps
+// the total number of items rather than the page size.
+func (r *ExportService) All(ctx context.Context, query ExportListParams, opts ...option.RequestOption) iter.Seq2[Export, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[Export], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists External Accounts, like [ExternalAccountService.ListAutoPaging], as
+// a sequence for range. Pages are fetched as it is iterated, and the Limit of
+// the query caps the total number of items rather than the page size.
+func (r *ExternalAccountService) All(ctx context.Context, query ExternalAccountListParams, opts ...option.RequestOption) iter.Seq2[ExternalAccount, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[ExternalAccount], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Files, like [FileService.ListAutoPaging], as a sequence for range.
+// Pages are fetched as it is iterated, and the Limit of the query caps the
+// total number of items rather than the page size.
+func (r *FileService) All(ctx context.Context, query FileListParams, opts ...option.RequestOption) iter.Seq2[File, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[File], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Inbound ACH Transfers, like
+// [InboundACHTransferService.ListAutoPaging], as a sequence for range. Pages
+// are fetched as it is iterated, and the Limit of the query caps the total
+// number of items rather than the page size.
+func (r *InboundACHTransferService) All(ctx context.Context, query InboundACHTransferListParams, opts ...option.RequestOption) iter.Seq2[InboundACHTransfer, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[InboundACHTransfer], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Inbound Wire Drawdown Requests, like
+// [InboundWireDrawdownRequestService.ListAutoPaging], as a sequence for range.
+// Pages are fetched as it is iterated, and the Limit of the query caps the
+// total number of items rather than the page size.
+func (r *InboundWireDrawdownRequestService) All(ctx context.Context, query InboundWireDrawdownRequestListParams, opts ...option.RequestOption) iter.Seq2[InboundWireDrawdownRequest, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[InboundWireDrawdownRequest], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists OAuth Connections, like [OauthConnectionService.ListAutoPaging], as
+// a sequence for range. Pages are fetched as it is iterated, and the Limit of
+// the query caps the total number of items rather than the page size.
+func (r *OauthConnectionService) All(ctx context.Context, query OauthConnectionListParams, opts ...option.RequestOption) iter.Seq2[OauthConnection, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[OauthConnection], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Pending Transactions, like
+// [PendingTransactionService.ListAutoPaging], as a sequence for range. Pages
+// are fetched as it is iterated, and the Limit of the query caps the total
+// number of items rather than the page size.
+func (r *PendingTransactionService) All(ctx context.Context, query PendingTransactionListParams, opts ...option.RequestOption) iter.Seq2[PendingTransaction, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[PendingTransaction], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Physical Cards, like [PhysicalCardService.ListAutoPaging], as a
+// sequence for range. Pages are fetched as it is iterated, and the Limit of the
+// query caps the total number of items rather than the page size.
+func (r *PhysicalCardService) All(ctx context.Context, query PhysicalCardListParams, opts ...option.RequestOption) iter.Seq2[PhysicalCard, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[PhysicalCard], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Programs, like [ProgramService.ListAutoPaging], as a sequence for
+// range. Pages are fetched as it is iterated, and the Limit of the query caps
+// the total number of items rather than the page size.
+func (r *ProgramService) All(ctx context.Context, query ProgramListParams, opts ...option.RequestOption) iter.Seq2[Program, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[Program], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Real-Time Payments Transfers, like
+// [RealTimePaymentsTransferService.ListAutoPaging], as a sequence for range.
+// Pages are fetched as it is iterated, and the Limit of the query caps the
+// total number of items rather than the page size.
+func (r *RealTimePaymentsTransferService) All(ctx context.Context, query RealTimePaymentsTransferListParams, opts ...option.RequestOption) iter.Seq2[RealTimePaymentsTransfer, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[RealTimePaymentsTransfer], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists the Routing Numbers matching the query, like
+// [RoutingNumberService.ListAutoPaging], as a sequence for range. Pages are
+// fetched as it is iterated, and the Limit of the query caps the total number
+// of items rather than the page size.
+func (r *RoutingNumberService) All(ctx context.Context, query RoutingNumberListParams, opts ...option.RequestOption) iter.Seq2[RoutingNumber, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[RoutingNumber], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Transactions, like [TransactionService.ListAutoPaging], as a
+// sequence for range. Pages are fetched as it is iterated, and the Limit of the
+// query caps the total number of items rather than the page size.
+func (r *TransactionService) All(ctx context.Context, query TransactionListParams, opts ...option.RequestOption) iter.Seq2[Transaction, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[Transaction], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Wire Drawdown Requests, like
+// [WireDrawdownRequestService.ListAutoPaging], as a sequence for range. Pages
+// are fetched as it is iterated, and the Limit of the query caps the total
+// number of items rather than the page size.
+func (r *WireDrawdownRequestService) All(ctx context.Context, query WireDrawdownRequestListParams, opts ...option.RequestOption) iter.Seq2[WireDrawdownRequest, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[WireDrawdownRequest], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
+
+// All lists Wire Transfers, like [WireTransferService.ListAutoPaging], as a
+// sequence for range. Pages are fetched as it is iterated, and the Limit of the
+// query caps the total number of items rather than the page size.
+func (r *WireTransferService) All(ctx context.Context, query WireTransferListParams, opts ...option.RequestOption) iter.Seq2[WireTransfer, error] {
+	limit := totalLimit(&query.Limit)
+	return shared.All(ctx, limit, func() (*shared.Page[WireTransfer], error) {
+		return r.List(ctx, query, opts...)
+	})
+}
@@ -0,0 +1,100 @@
+//go:build go1.23
+
+package increase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/increase/increase-go"
+)
+
+func TestAll(t *testing.T) {
+	client, transport := newPagedClient(250)
+	n := 0
+	for account, err := range client.Accounts.All(context.Background(), increase.AccountListParams{}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if account.ID != fmt.Sprintf("account_%d", n) {
+			t.Fatalf("expected account_%d, got %s", n, account.ID)
+		}
+		n++
+	}
+	if n != 250 || transport.requests() != 3 {
+		t.Fatalf("expected 250 accounts in 3 pages, got %d in %d", n, transport.requests())
+	}
+}
+
+func TestAllLimitCapsTotal(t *testing.T) {
+	client, transport := newPagedClient(1000)
+	n := 0
+	for _, err := range client.Accounts.All(context.Background(), increase.AccountListParams{Limit: increase.F(int64(150))}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		n++
+	}
+	if n != 150 || transport.requests() != 2 || transport.queries[0] != "limit=100" {
+		t.Fatalf("expected 150 accounts from pages of 100, got %d from %v", n, transport.queries)
+	}
+}
+
+func TestAllStopsOnBreak(t *testing.T) {
+	client, transport := newPagedClient(1000)
+	seq := client.Accounts.All(context.Background(), increase.AccountListParams{})
+	if transport.requests() != 0 {
+		t.Fatal("expected no request before iterating")
+	}
+	for account, err := range seq {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if account.ID == "account_150" {
+			break
+		}
+	}
+	if transport.requests() != 2 {
+		t.Fatalf("expected no more pages to be fetched after break, got %d", transport.requests())
+	}
+}
+
+func TestAllStopsOnCancel(t *testing.T) {
+	client, _ := newPagedClient(1000)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	n := 0
+	var last error
+	for _, err := range client.Accounts.All(ctx, increase.AccountListParams{}) {
+		if err != nil {
+			last = err
+			continue
+		}
+		if n++; n == 10 {
+			cancel()
+		}
+	}
+	if n != 10 || !errors.Is(last, context.Canceled) {
+		t.Fatalf("expected iteration to stop with context.Canceled after 10 accounts, got %d and %v", n, last)
+	}
+}
+
+func TestPages(t *testing.T) {
+	client, _ := newPagedClient(250)
+	page, err := client.Accounts.List(context.Background(), increase.AccountListParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sizes []int
+	for page, err := range page.Pages() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		sizes = append(sizes, len(page.Data))
+	}
+	if fmt.Sprint(sizes) != "[100 100 50]" {
+		t.Fatalf("expected pages of 100, 100 and 50 accounts, got %v", sizes)
+	}
+}
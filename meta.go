@@ -0,0 +1,24 @@
+package increase
+
+import (
+	"github.com/increase/increase-go/option"
+)
+
+// ResponseMeta describes how a request was answered, such as its request ID.
+type ResponseMeta = option.ResponseMeta
+
+// WithMeta calls a service method and returns the metadata of its response
+// along with its result. The method is given an option that captures the
+// metadata, which it has to pass on:
+//
+//	account, meta, err := increase.WithMeta(func(opts ...option.RequestOption) (*increase.Account, error) {
+//		return client.Accounts.Get(ctx, accountID, opts...)
+//	})
+//	fmt.Println(meta.RequestID)
+//
+// The metadata is returned even if the call fails.
+func WithMeta[T any](call func(opts ...option.RequestOption) (T, error)) (T, ResponseMeta, error) {
+	var meta ResponseMeta
+	res, err := call(option.WithResponseMetaInto(&meta))
+	return res, meta, err
+}
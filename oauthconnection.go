@@ -63,7 +63,7 @@ func (r *OauthConnectionService) List(ctx context.Context, query OauthConnection
 
 // List OAuth Connections
 func (r *OauthConnectionService) ListAutoPaging(ctx context.Context, query OauthConnectionListParams, opts ...option.RequestOption) *shared.PageAutoPager[OauthConnection] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // When a user authorizes your OAuth application, an OAuth Connection object is
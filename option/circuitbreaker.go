@@ -0,0 +1,37 @@
+package option
+
+import (
+	"github.com/increase/increase-go/internal/circuitbreaker"
+)
+
+// CircuitBreaker fails requests fast, with an *increase.CircuitOpenError, once
+// too many recent requests to the same host (and, optionally, path prefix)
+// failed with a connection error or a 5xx status code. After a timeout, it lets
+// probe requests through to find out whether the API has recovered. Create one
+// with [NewCircuitBreaker].
+type CircuitBreaker = circuitbreaker.Breaker
+
+// CircuitBreakerConfig configures a [CircuitBreaker].
+type CircuitBreakerConfig = circuitbreaker.Config
+
+// CircuitState is the state of one of the circuits of a [CircuitBreaker].
+type CircuitState = circuitbreaker.State
+
+const (
+	CircuitStateClosed   = circuitbreaker.StateClosed
+	CircuitStateOpen     = circuitbreaker.StateOpen
+	CircuitStateHalfOpen = circuitbreaker.StateHalfOpen
+)
+
+// NewCircuitBreaker returns a new [CircuitBreaker]. Its Middleware method can be
+// given to [WithMiddleware] directly, or through [WithCircuitBreaker].
+func NewCircuitBreaker(cfg CircuitBreakerConfig) *CircuitBreaker {
+	return circuitbreaker.New(cfg)
+}
+
+// WithCircuitBreaker returns a RequestOption that sends every request attempt
+// through the given circuit breaker. Requests failed fast by an open circuit are
+// not retried.
+func WithCircuitBreaker(breaker *CircuitBreaker) RequestOption {
+	return WithMiddleware(breaker.Middleware)
+}
@@ -0,0 +1,59 @@
+package option
+
+import (
+	"github.com/increase/increase-go/internal/config"
+)
+
+// LoadConfig returns the options for the settings of the given profile of the
+// config file, overridden by environment variables. The config file is
+// INCREASE_CONFIG_FILE if set, or else ~/.config/increase/config.toml (or its
+// equivalent on the platform). If profile is empty, INCREASE_PROFILE is used, or
+// else "default".
+//
+// A config file holds a table per profile; keys before the first table apply to
+// every profile:
+//
+//	max_retries = 3
+//
+//	[default]
+//	api_key = "..."
+//	environment = "sandbox"
+//
+//	[program_b]
+//	api_key = "..."
+//	base_url = "https://increase-proxy.internal/"
+//	timeout = "30s"
+//
+// The INCREASE_API_KEY, INCREASE_ENVIRONMENT (production or sandbox),
+// INCREASE_BASE_URL, INCREASE_MAX_RETRIES and INCREASE_TIMEOUT environment
+// variables take precedence over the file, so INCREASE_ENVIRONMENT replaces a
+// base_url of the profile. Otherwise a base URL takes precedence over the
+// environment.
+func LoadConfig(profile string) ([]RequestOption, error) {
+	return LoadConfigFile(config.DefaultPath(), profile)
+}
+
+// LoadConfigFile is like [LoadConfig], but reads the config file at path.
+func LoadConfigFile(path string, profile string) ([]RequestOption, error) {
+	settings, err := config.Load(path, profile)
+	if err != nil {
+		return nil, err
+	}
+	var opts []RequestOption
+	if settings.Environment != "" {
+		opts = append(opts, WithBaseURL(config.Environments[settings.Environment]))
+	}
+	if settings.BaseURL != "" {
+		opts = append(opts, WithBaseURL(settings.BaseURL))
+	}
+	if settings.APIKey != "" {
+		opts = append(opts, WithAPIKey(settings.APIKey))
+	}
+	if settings.MaxRetries != nil {
+		opts = append(opts, WithMaxRetries(*settings.MaxRetries))
+	}
+	if settings.Timeout > 0 {
+		opts = append(opts, WithRequestTimeout(settings.Timeout))
+	}
+	return opts, nil
+}
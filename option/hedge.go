@@ -0,0 +1,29 @@
+package option
+
+import (
+	"github.com/increase/increase-go/internal/hedge"
+)
+
+// Hedger cuts the tail latency of reads, such as fetching a Card or a Real-Time
+// Decision on a latency-critical path. When a GET request takes longer than a
+// percentile of the recent latencies of its endpoint, it sends the request a
+// second time and returns whichever successful response arrives first,
+// canceling the other. Other methods are never hedged. Create one with
+// [NewHedger].
+type Hedger = hedge.Hedger
+
+// HedgerConfig configures a [Hedger].
+type HedgerConfig = hedge.Config
+
+// NewHedger returns a new [Hedger]. Its Middleware method can be given to
+// [WithMiddleware] directly, or through [WithHedging].
+func NewHedger(cfg HedgerConfig) *Hedger {
+	return hedge.New(cfg)
+}
+
+// WithHedging returns a RequestOption that sends every attempt of GET, HEAD and
+// OPTIONS requests through the given hedger. Share one hedger between requests
+// so that it learns the latencies of each endpoint.
+func WithHedging(hedger *Hedger) RequestOption {
+	return WithMiddleware(hedger.Middleware)
+}
@@ -0,0 +1,49 @@
+package option
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/increase/increase-go/internal/requestconfig"
+)
+
+// idempotencyKeyNamespace scopes the keys derived by [DeriveIdempotencyKey], so
+// that they don't collide with other UUIDs derived from the same names.
+var idempotencyKeyNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com/increase/increase-go#idempotency-key"))
+
+// WithIdempotencyKey returns a RequestOption that sends the given
+// `Idempotency-Key` instead of a random one, so that a request can be safely
+// repeated, even from another process, without acting twice. It only applies to
+// mutating requests such as [increase.ACHTransferService.New]; GET requests are
+// idempotent already and are sent without a key.
+//
+// Use it per request, never on the client: every distinct operation needs its
+// own key.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(r *requestconfig.RequestConfig) error {
+		if !requestconfig.IsMutating(r.Request.Method) {
+			return nil
+		}
+		r.IdempotencyKey = key
+		r.Request.Header.Set("Idempotency-Key", key)
+		return nil
+	}
+}
+
+// WithDerivedIdempotencyKey returns a RequestOption that sends the key returned
+// by [DeriveIdempotencyKey] for the given parts.
+func WithDerivedIdempotencyKey(parts ...string) RequestOption {
+	return WithIdempotencyKey(DeriveIdempotencyKey(parts...))
+}
+
+// DeriveIdempotencyKey returns a stable idempotency key for a business
+// operation, such as an invoice ID and the action taken on it. The same parts
+// always produce the same key, so an operation retried after a crash and
+// restart is recognized by the API rather than performed twice:
+//
+//	client.ACHTransfers.New(ctx, params,
+//		option.WithIdempotencyKey(option.DeriveIdempotencyKey("invoice", invoice.ID, "payout")),
+//	)
+func DeriveIdempotencyKey(parts ...string) string {
+	return uuid.NewSHA1(idempotencyKeyNamespace, []byte(strings.Join(parts, "\x00"))).String()
+}
@@ -0,0 +1,38 @@
+package option_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/increase/increase-go/internal/requestconfig"
+	"github.com/increase/increase-go/option"
+)
+
+func TestDeriveIdempotencyKey(t *testing.T) {
+	key := option.DeriveIdempotencyKey("invoice", "inv_123", "payout")
+	if key != option.DeriveIdempotencyKey("invoice", "inv_123", "payout") {
+		t.Fatal("expected the same parts to derive the same key")
+	}
+	if key == option.DeriveIdempotencyKey("invoice", "inv_12", "3payout") {
+		t.Fatal("expected differently split parts to derive different keys")
+	}
+}
+
+func TestWithIdempotencyKey(t *testing.T) {
+	post, err := requestconfig.NewRequestConfig(context.Background(), http.MethodPost, "ach_transfers", nil, nil, option.WithIdempotencyKey("my-key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key := post.Request.Header.Get("Idempotency-Key"); key != "my-key" {
+		t.Fatalf("expected the caller's key to be sent, got %q", key)
+	}
+
+	get, err := requestconfig.NewRequestConfig(context.Background(), http.MethodGet, "ach_transfers", nil, nil, option.WithIdempotencyKey("my-key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key := get.Request.Header.Get("Idempotency-Key"); key != "" {
+		t.Fatalf("expected GET requests to be sent without a key, got %q", key)
+	}
+}
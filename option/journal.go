@@ -0,0 +1,51 @@
+package option
+
+import (
+	"github.com/increase/increase-go/internal/journal"
+	"github.com/increase/increase-go/internal/requestconfig"
+)
+
+// JournalStore persists the entries of an idempotency journal. Use
+// [NewMemoryJournal] or [NewFileJournal], or implement it on top of your own
+// database.
+type JournalStore = requestconfig.JournalStore
+
+// JournalEntry records a mutating request sent with a caller-supplied
+// idempotency key, and its response once the API accepted it.
+type JournalEntry = requestconfig.JournalEntry
+
+// JournalStatus is the state of a [JournalEntry].
+type JournalStatus = requestconfig.JournalStatus
+
+const (
+	JournalStatusPending   = requestconfig.JournalStatusPending
+	JournalStatusCompleted = requestconfig.JournalStatusCompleted
+)
+
+// NewMemoryJournal returns a [JournalStore] that is kept in memory.
+func NewMemoryJournal() JournalStore {
+	return journal.NewMemoryStore()
+}
+
+// NewFileJournal returns a [JournalStore] that keeps one file per entry in the
+// given directory, and syncs each entry to disk before the request is sent.
+func NewFileJournal(dir string) (JournalStore, error) {
+	return journal.NewFileStore(dir)
+}
+
+// WithJournal returns a RequestOption that records every mutating request sent
+// with [WithIdempotencyKey] in the given journal, together with its response
+// once the API accepted it. When a request is made again with a key whose
+// response is recorded, for example by a payout worker that restarted after a
+// crash, the recorded response is returned instead of sending the request. A
+// key can only be used again for the same request: a different method, URL or
+// body is an error. Requests the API rejected, such as with a 401 or a 409, are
+// removed from the journal. Requests whose outcome is unknown stay pending,
+// and are sent again with the same key, either when they are made again or by
+// [increase.Client.ReplayJournal].
+func WithJournal(store JournalStore) RequestOption {
+	return func(r *requestconfig.RequestConfig) error {
+		r.Journal = store
+		return nil
+	}
+}
@@ -0,0 +1,41 @@
+package option
+
+import (
+	"log/slog"
+
+	"github.com/increase/increase-go/internal/requestconfig"
+)
+
+// WithLogger returns a RequestOption that logs every request attempt to the
+// given logger, with its method, path, status, attempt number, latency and
+// `Idempotency-Key`. When the logger is enabled for [slog.LevelDebug], the
+// headers and JSON bodies are logged too, with the `Authorization` header and
+// sensitive fields such as card numbers, verification codes, account numbers
+// and routing numbers redacted.
+func WithLogger(logger *slog.Logger) RequestOption {
+	return func(r *requestconfig.RequestConfig) error {
+		r.Logger = logger
+		return nil
+	}
+}
+
+// WithLogRedactedHeaders returns a RequestOption that redacts the given headers
+// from the logs, in addition to the `Authorization` header.
+func WithLogRedactedHeaders(headers ...string) RequestOption {
+	return func(r *requestconfig.RequestConfig) error {
+		r.LogRedactedHeaders = append(r.LogRedactedHeaders, headers...)
+		return nil
+	}
+}
+
+// WithLogRedactedFields returns a RequestOption that redacts the given JSON
+// fields from logged bodies, in addition to the default ones. A field is
+// redacted when its name equals one of the given names, or ends with an
+// underscore followed by one, so "account_number" also covers
+// "debtor_account_number".
+func WithLogRedactedFields(fields ...string) RequestOption {
+	return func(r *requestconfig.RequestConfig) error {
+		r.LogRedactedFields = append(r.LogRedactedFields, fields...)
+		return nil
+	}
+}
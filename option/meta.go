@@ -0,0 +1,19 @@
+package option
+
+import (
+	"github.com/increase/increase-go/internal/requestconfig"
+)
+
+// ResponseMeta describes how a request was answered: its request ID, the
+// idempotency key it was sent with, whether the response was replayed, the rate
+// limit headers, the number of attempts and the total latency.
+type ResponseMeta = requestconfig.ResponseMeta
+
+// WithResponseMetaInto returns a RequestOption that copies the metadata of the
+// response into the given address. It is filled in even if the request fails.
+func WithResponseMetaInto(dst *ResponseMeta) RequestOption {
+	return func(r *requestconfig.RequestConfig) error {
+		r.ResponseMetaInto = dst
+		return nil
+	}
+}
@@ -0,0 +1,22 @@
+package option
+
+import (
+	"github.com/increase/increase-go/internal/requestconfig"
+)
+
+// WithPrefetch returns a RequestOption that makes auto-paging, with
+// ListAutoPaging or All, fetch up to the given number of pages ahead, in the
+// background, while the caller goes through the current one. The pages fetched
+// ahead are dropped when the iteration stops, or after they have not been taken
+// for 30 seconds, in case the iteration is abandoned without Close.
+//
+// WithPrefetch panics when pages is negative.
+func WithPrefetch(pages int) RequestOption {
+	if pages < 0 {
+		panic("option: cannot prefetch fewer than 0 pages")
+	}
+	return func(r *requestconfig.RequestConfig) error {
+		r.Prefetch = pages
+		return nil
+	}
+}
@@ -0,0 +1,31 @@
+package option
+
+import (
+	"github.com/increase/increase-go/internal/ratelimit"
+)
+
+// RateLimiter throttles the requests of every client, service and request it is
+// given to, using a token bucket per API key and optional per-endpoint buckets.
+// It slows down when the API responds with 429 Too Many Requests and honors the
+// `Retry-After` header. Create one with [NewRateLimiter].
+type RateLimiter = ratelimit.Limiter
+
+// RateLimiterConfig configures a [RateLimiter].
+type RateLimiterConfig = ratelimit.Config
+
+// EndpointRateLimit limits requests to paths matching a pattern such as
+// "/ach_transfers" or "/simulations/*", on top of the overall rate.
+type EndpointRateLimit = ratelimit.EndpointLimit
+
+// NewRateLimiter returns a new [RateLimiter]. Share it between clients by
+// passing it to [WithRateLimiter] for each of them.
+func NewRateLimiter(cfg RateLimiterConfig) *RateLimiter {
+	return ratelimit.New(cfg)
+}
+
+// WithRateLimiter returns a RequestOption that makes every request attempt wait
+// for the given limiter before it is sent. When given to the client, the limiter
+// applies across all of the client's services.
+func WithRateLimiter(limiter *RateLimiter) RequestOption {
+	return WithMiddleware(limiter.Middleware)
+}
@@ -33,7 +33,7 @@ func WithBaseURL(base string) RequestOption {
 }
 
 // WithHTTPClient returns a RequestOption that changes the underlying [http.Client] used to make this
-// request, which by default is [http.DefaultClient].
+// request, which by default is a client shared by all clients, tuned with [DefaultTransportConfig].
 func WithHTTPClient(client *http.Client) RequestOption {
 	return func(r *requestconfig.RequestConfig) error {
 		r.HTTPClient = client
@@ -0,0 +1,62 @@
+package option
+
+import (
+	"time"
+
+	"github.com/increase/increase-go/internal/requestconfig"
+)
+
+// RetryPolicy decides whether a failed request attempt should be retried, how
+// long to wait before doing so, and how much time all attempts of a request may
+// take together. The number of retries is bounded separately by [WithMaxRetries].
+type RetryPolicy = requestconfig.RetryPolicy
+
+// RetryAttempt describes a finished request attempt that a [RetryPolicy] is
+// asked to judge. When the API responded with an error status, Err holds the
+// decoded *increase.Error.
+type RetryAttempt = requestconfig.RetryAttempt
+
+// ExponentialRetryPolicy doubles the delay between retries, starting at 0.5
+// seconds and capped at 8 seconds unless configured otherwise. This is the
+// policy used by default.
+type ExponentialRetryPolicy = requestconfig.ExponentialRetryPolicy
+
+// DecorrelatedJitterRetryPolicy picks each delay at random between a base delay
+// and three times the previous delay.
+type DecorrelatedJitterRetryPolicy = requestconfig.DecorrelatedJitterRetryPolicy
+
+// ConstantRetryPolicy waits the same delay before every retry.
+type ConstantRetryPolicy = requestconfig.ConstantRetryPolicy
+
+// WithRetryPolicy returns a RequestOption that replaces the policy used to decide
+// which failed requests are retried and how long to wait in between.
+//
+// For example, a batch job can afford a much longer retry budget than a
+// latency-sensitive request:
+//
+//	client.ACHTransfers.New(ctx, params,
+//		option.WithMaxRetries(10),
+//		option.WithRetryPolicy(&option.ExponentialRetryPolicy{
+//			MaxDelay:   time.Minute,
+//			MaxElapsed: 30 * time.Minute,
+//		}),
+//	)
+func WithRetryPolicy(policy RetryPolicy) RequestOption {
+	return func(r *requestconfig.RequestConfig) error {
+		r.RetryPolicy = policy
+		return nil
+	}
+}
+
+// DefaultShouldRetry reports whether the attempt would be retried by the default
+// policy. It is useful for custom policies that only want to change the delay.
+func DefaultShouldRetry(attempt RetryAttempt) bool {
+	return requestconfig.DefaultShouldRetry(attempt)
+}
+
+// RetryAfter returns the delay the API asked for before retrying, taken from the
+// `Retry-After` header (either delay-seconds or an HTTP-date) or the
+// `retry_after` field of the error body.
+func RetryAfter(attempt RetryAttempt) (time.Duration, bool) {
+	return requestconfig.RetryAfter(attempt)
+}
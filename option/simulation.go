@@ -0,0 +1,25 @@
+package option
+
+import (
+	"net/url"
+
+	"github.com/increase/increase-go/internal/requestconfig"
+)
+
+// WithSimulationBaseURL returns a RequestOption that lets the simulation
+// methods send requests to the host of the given base URL, such as a mock
+// server or a proxy in front of the sandbox. Without it, simulations are only
+// sent to the sandbox environment. It does not change the base URL requests are
+// sent to; use [WithBaseURL] for that.
+//
+// WithSimulationBaseURL panics when base is not a valid URL.
+func WithSimulationBaseURL(base string) RequestOption {
+	u, err := url.Parse(base)
+	if err != nil || u.Host == "" {
+		panic("option: invalid simulation base URL " + base)
+	}
+	return func(r *requestconfig.RequestConfig) error {
+		r.SimulationHosts = append(r.SimulationHosts[:len(r.SimulationHosts):len(r.SimulationHosts)], u.Host)
+		return nil
+	}
+}
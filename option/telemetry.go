@@ -0,0 +1,30 @@
+package option
+
+import (
+	"github.com/increase/increase-go/internal/requestconfig"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithTracerProvider returns a RequestOption that traces every SDK method call
+// with the given provider. Each call gets a span named after its service and
+// method, such as "ACHTransferService.New", with the resource ID, idempotency
+// key, retry count and API error type as attributes, and a child span for each
+// attempt.
+func WithTracerProvider(provider trace.TracerProvider) RequestOption {
+	return func(r *requestconfig.RequestConfig) error {
+		r.TracerProvider = provider
+		return nil
+	}
+}
+
+// WithMeterProvider returns a RequestOption that records the duration and the
+// number of retries of every SDK method call, as the
+// "increase.client.operation.duration" and "increase.client.operation.retries"
+// histograms, with the operation, status code and error type as attributes.
+func WithMeterProvider(provider metric.MeterProvider) RequestOption {
+	return func(r *requestconfig.RequestConfig) error {
+		r.MeterProvider = provider
+		return nil
+	}
+}
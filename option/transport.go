@@ -0,0 +1,34 @@
+package option
+
+import (
+	"net/http"
+
+	"github.com/increase/increase-go/internal/requestconfig"
+)
+
+// TransportConfig tunes the connection pool, timeouts and HTTP/2 health checks
+// of the client's transport.
+type TransportConfig = requestconfig.TransportConfig
+
+// DefaultTransportConfig returns the settings used when neither
+// [WithTransportConfig] nor [WithHTTPClient] is given.
+func DefaultTransportConfig() TransportConfig {
+	return requestconfig.DefaultTransportConfig()
+}
+
+// WithTransportConfig returns a RequestOption that sends requests through a
+// transport with the given settings, in place of the default one. The transport
+// is created once, by this function, so give the option to the client, or reuse
+// it, rather than creating it for every request. It replaces any client given
+// with [WithHTTPClient] before it.
+func WithTransportConfig(config TransportConfig) RequestOption {
+	transport, err := requestconfig.NewTransport(config)
+	client := &http.Client{Transport: transport}
+	return func(r *requestconfig.RequestConfig) error {
+		if err != nil {
+			return err
+		}
+		r.HTTPClient = client
+		return nil
+	}
+}
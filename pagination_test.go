@@ -0,0 +1,61 @@
+package increase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/increase/increase-go"
+	"github.com/increase/increase-go/option"
+)
+
+// newPagedClient returns a client listing total accounts.
+func newPagedClient(total int) (*increase.Client, *fakeTransport) {
+	transport := &fakeTransport{}
+	for i := 0; i < total; i++ {
+		transport.add(fmt.Sprintf("account_%d", i), time.Time{})
+	}
+	return newFakeClient(transport), transport
+}
+
+func TestListAutoPagingPrefetch(t *testing.T) {
+	client, transport := newPagedClient(1000)
+	iter := client.Accounts.ListAutoPaging(context.Background(), increase.AccountListParams{Limit: increase.F(int64(10))}, option.WithPrefetch(2))
+	n := 0
+	for iter.Next() {
+		if iter.Current().ID != fmt.Sprintf("account_%d", n) {
+			t.Fatalf("expected account_%d, got %s", n, iter.Current().ID)
+		}
+		n++
+	}
+	if err := iter.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 1000 || transport.requests() != 100 {
+		t.Fatalf("expected 1000 accounts in 100 pages, got %d in %d", n, transport.requests())
+	}
+}
+
+func TestListAutoPagingPrefetchIsBounded(t *testing.T) {
+	client, transport := newPagedClient(1000)
+	iter := client.Accounts.ListAutoPaging(context.Background(), increase.AccountListParams{Limit: increase.F(int64(10))}, option.WithPrefetch(2))
+	if !iter.Next() {
+		t.Fatal(iter.Err())
+	}
+	// The first page, and the two pages after it.
+	deadline := time.Now().Add(time.Second)
+	for transport.requests() < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if requests := transport.requests(); requests != 3 {
+		t.Fatalf("expected 2 pages to be fetched ahead, got %d requests", requests)
+	}
+
+	iter.Close()
+	time.Sleep(20 * time.Millisecond)
+	if requests := transport.requests(); requests != 3 || iter.Next() {
+		t.Fatalf("expected the iteration to stop, got %d requests", requests)
+	}
+}
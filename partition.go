@@ -0,0 +1,248 @@
+package increase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/increase/increase-go/internal/param"
+	"github.com/increase/increase-go/internal/shared"
+	"github.com/increase/increase-go/option"
+)
+
+// PartitionConfig configures [ListPartitioned].
+type PartitionConfig struct {
+	// The creation times to list, from Start (inclusive) to End (exclusive). End
+	// defaults to now.
+	Start time.Time
+	End   time.Time
+	// The number of windows the range is split into. Defaults to Workers.
+	Partitions int
+	// The number of windows listed at the same time. Defaults to 4.
+	Workers int
+	// Unordered hands over items as soon as any window returns them. By default,
+	// windows are handed over one after the other, newest first, each in the
+	// order of the list endpoint, so that items come in the order listing the
+	// whole range would return them.
+	Unordered bool
+	// The number of items each window holds while it waits for its turn, when
+	// ordered. A window which has filled its buffer stops listing until it is
+	// handed over, so windows holding more items than this are listed mostly one
+	// after the other. Defaults to 10,000.
+	Buffer int
+}
+
+// partitionBatch is the number of items a window sends at a time.
+const partitionBatch = 100
+
+// ListPartitioned lists every item created within a range by splitting the range
+// into windows, which are listed concurrently, and passes the items to each. It
+// takes the ListAutoPaging method of a service and its list params, whose
+// CreatedAt filter must be left unset:
+//
+//	err := increase.ListPartitioned(ctx, client.Transactions.ListAutoPaging, increase.TransactionListParams{}, increase.PartitionConfig{
+//		Start:      time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+//		Partitions: 16,
+//		Workers:    8,
+//	}, func(transaction increase.Transaction) error {
+//		return export(transaction)
+//	})
+//
+// each is never called concurrently. Listing stops with the first error, from
+// a request or returned by each.
+func ListPartitioned[P any, T any](ctx context.Context, list func(context.Context, P, ...option.RequestOption) *shared.PageAutoPager[T], params P, cfg PartitionConfig, each func(T) error) error {
+	if cfg.End.IsZero() {
+		cfg.End = time.Now()
+	}
+	if !cfg.Start.Before(cfg.End) {
+		return fmt.Errorf("partition start %s is not before its end %s", cfg.Start, cfg.End)
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = 4
+	}
+	if cfg.Partitions <= 0 {
+		cfg.Partitions = cfg.Workers
+	}
+	if cfg.Buffer <= 0 {
+		cfg.Buffer = 10_000
+	}
+
+	windows := partitionWindows(cfg.Start, cfg.End, cfg.Partitions)
+	windowParams := make([]P, len(windows))
+	for i, w := range windows {
+		var err error
+		windowParams[i], err = withCreatedAt(params, map[string]time.Time{"OnOrAfter": w[0], "Before": w[1]})
+		if err != nil {
+			return err
+		}
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+	var errOnce sync.Once
+	var firstErr error
+	fail := func(err error) {
+		errOnce.Do(func() { firstErr = err })
+		cancel()
+	}
+
+	// Each window sends batches of its items to its own channel, unless they
+	// are unordered.
+	outs := make([]chan []T, len(windows))
+	if cfg.Unordered {
+		out := make(chan []T, cfg.Workers)
+		for i := range outs {
+			outs[i] = out
+		}
+	} else {
+		for i := range outs {
+			outs[i] = make(chan []T, (cfg.Buffer+partitionBatch-1)/partitionBatch)
+		}
+	}
+
+	jobs := make(chan int)
+	go func() {
+		defer close(jobs)
+		for i := range windows {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	var wg sync.WaitGroup
+	for w := 0; w < cfg.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := listWindow(ctx, list, windowParams[i], outs[i]); err != nil {
+					fail(err)
+				}
+				if !cfg.Unordered {
+					close(outs[i])
+				}
+			}
+		}()
+	}
+	consumed := outs
+	if cfg.Unordered {
+		go func() {
+			wg.Wait()
+			close(outs[0])
+		}()
+		consumed = outs[:1]
+	}
+
+consume:
+	for _, out := range consumed {
+		for {
+			// A window may never be listed once the context is canceled.
+			var batch []T
+			var ok bool
+			select {
+			case batch, ok = <-out:
+			case <-ctx.Done():
+				break consume
+			}
+			if !ok {
+				break
+			}
+			for _, item := range batch {
+				if err := each(item); err != nil {
+					fail(err)
+					break consume
+				}
+			}
+		}
+	}
+	cancel()
+	wg.Wait()
+	if firstErr == nil {
+		// The parent context may have been canceled or run out of time.
+		firstErr = parent.Err()
+	}
+	return firstErr
+}
+
+func listWindow[P any, T any](ctx context.Context, list func(context.Context, P, ...option.RequestOption) *shared.PageAutoPager[T], params P, out chan<- []T) error {
+	pager := list(ctx, params)
+	defer pager.Close()
+	var batch []T
+	send := func() bool {
+		select {
+		case out <- batch:
+			batch = nil
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	for pager.Next() {
+		batch = append(batch, pager.Current())
+		if len(batch) == partitionBatch && !send() {
+			return nil
+		}
+	}
+	if err := pager.Err(); err != nil && ctx.Err() == nil {
+		return err
+	}
+	if len(batch) > 0 {
+		send()
+	}
+	return nil
+}
+
+// partitionWindows splits [start, end) into n windows of the same length, from
+// the newest to the oldest.
+func partitionWindows(start, end time.Time, n int) [][2]time.Time {
+	length := end.Sub(start) / time.Duration(n)
+	if length <= 0 {
+		return [][2]time.Time{{start, end}}
+	}
+	windows := make([][2]time.Time, 0, n)
+	for i := n - 1; i >= 0; i-- {
+		windowStart, windowEnd := start.Add(time.Duration(i)*length), start.Add(time.Duration(i+1)*length)
+		if i == n-1 {
+			windowEnd = end
+		}
+		windows = append(windows, [2]time.Time{windowStart, windowEnd})
+	}
+	return windows
+}
+
+var timeFieldType = reflect.TypeOf(param.Field[time.Time]{})
+
+// withCreatedAt returns a copy of the list params with the given CreatedAt
+// filters, such as "OnOrAfter", set.
+func withCreatedAt[P any](params P, filters map[string]time.Time) (P, error) {
+	v := reflect.ValueOf(&params).Elem()
+	if v.Kind() != reflect.Struct {
+		return params, fmt.Errorf("%T are not list params", params)
+	}
+	createdAt := v.FieldByName("CreatedAt")
+	if !createdAt.IsValid() {
+		return params, fmt.Errorf("%T have no CreatedAt filter", params)
+	}
+	present, value := createdAt.FieldByName("Present"), createdAt.FieldByName("Value")
+	if !present.IsValid() || !value.IsValid() || value.Kind() != reflect.Struct {
+		return params, fmt.Errorf("%T have no CreatedAt filter", params)
+	}
+	if present.Bool() {
+		return params, errors.New("the CreatedAt filter of the list params must be unset")
+	}
+	for name, t := range filters {
+		field := value.FieldByName(name)
+		if !field.IsValid() || field.Type() != timeFieldType {
+			return params, fmt.Errorf("%T have no CreatedAt.%s filter", params, name)
+		}
+		field.Set(reflect.ValueOf(F(t)))
+	}
+	present.SetBool(true)
+	return params, nil
+}
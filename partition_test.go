@@ -0,0 +1,145 @@
+package increase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/increase/increase-go"
+)
+
+var partitionStart = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// newTimedClient returns a client listing total accounts, one created every
+// minute from partitionStart, in pages of 10.
+func newTimedClient(total int) (*increase.Client, *fakeTransport) {
+	transport := &fakeTransport{pageSize: 10}
+	for i := 0; i < total; i++ {
+		transport.add(fmt.Sprintf("account_%d", i), partitionStart.Add(time.Duration(i)*time.Minute))
+	}
+	return newFakeClient(transport), transport
+}
+
+func TestListPartitioned(t *testing.T) {
+	client, transport := newTimedClient(500)
+	var ids []string
+	err := increase.ListPartitioned(context.Background(), client.Accounts.ListAutoPaging, increase.AccountListParams{}, increase.PartitionConfig{
+		Start:      partitionStart,
+		End:        partitionStart.Add(500 * time.Minute),
+		Partitions: 7,
+		Workers:    3,
+	}, func(account increase.Account) error {
+		ids = append(ids, account.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 500 {
+		t.Fatalf("expected 500 accounts, got %d", len(ids))
+	}
+	for i, id := range ids {
+		if id != fmt.Sprintf("account_%d", 499-i) {
+			t.Fatalf("expected account_%d at %d, got %s", 499-i, i, id)
+		}
+	}
+	// Each of the 7 windows holds 71 or 72 accounts, in 8 pages.
+	if n := len(transport.queries); n != 56 {
+		t.Fatalf("expected 56 requests, got %d", n)
+	}
+}
+
+func TestListPartitionedOrderedListsWindowsAtOnce(t *testing.T) {
+	// Four windows of 500 accounts, in 50 pages each.
+	client, transport := newTimedClient(2000)
+	first := true
+	err := increase.ListPartitioned(context.Background(), client.Accounts.ListAutoPaging, increase.AccountListParams{}, increase.PartitionConfig{
+		Start:      partitionStart,
+		End:        partitionStart.Add(2000 * time.Minute),
+		Partitions: 4,
+		Workers:    4,
+	}, func(account increase.Account) error {
+		if !first {
+			return nil
+		}
+		first = false
+		// The other windows are listed while the first is handed over.
+		deadline := time.Now().Add(2 * time.Second)
+		for transport.requests() < 200 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		if n := transport.requests(); n != 200 {
+			t.Errorf("expected every window to be listed while the first is handed over, got %d of 200 requests", n)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListPartitionedUnordered(t *testing.T) {
+	client, _ := newTimedClient(500)
+	seen := map[string]bool{}
+	err := increase.ListPartitioned(context.Background(), client.Accounts.ListAutoPaging, increase.AccountListParams{}, increase.PartitionConfig{
+		Start:      partitionStart,
+		End:        partitionStart.Add(500 * time.Minute),
+		Partitions: 10,
+		Unordered:  true,
+	}, func(account increase.Account) error {
+		if seen[account.ID] {
+			t.Errorf("%s listed twice", account.ID)
+		}
+		seen[account.ID] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != 500 {
+		t.Fatalf("expected 500 accounts, got %d", len(seen))
+	}
+}
+
+func TestListPartitionedStopsOnError(t *testing.T) {
+	client, _ := newTimedClient(500)
+	stop := errors.New("stop")
+	n := 0
+	err := increase.ListPartitioned(context.Background(), client.Accounts.ListAutoPaging, increase.AccountListParams{}, increase.PartitionConfig{
+		Start:      partitionStart,
+		End:        partitionStart.Add(500 * time.Minute),
+		Partitions: 10,
+	}, func(account increase.Account) error {
+		n++
+		if n == 25 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected the error of each, got %v", err)
+	}
+	if n != 25 {
+		t.Fatalf("expected listing to stop after 25 accounts, got %d", n)
+	}
+}
+
+func TestListPartitionedRejectsCreatedAt(t *testing.T) {
+	client, transport := newTimedClient(10)
+	params := increase.AccountListParams{
+		CreatedAt: increase.F(increase.AccountListParamsCreatedAt{After: increase.F(partitionStart)}),
+	}
+	err := increase.ListPartitioned(context.Background(), client.Accounts.ListAutoPaging, params, increase.PartitionConfig{
+		Start: partitionStart,
+	}, func(account increase.Account) error {
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(transport.queries) != 0 {
+		t.Fatalf("expected no requests, got %d", len(transport.queries))
+	}
+}
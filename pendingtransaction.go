@@ -63,7 +63,7 @@ func (r *PendingTransactionService) List(ctx context.Context, query PendingTrans
 
 // List Pending Transactions
 func (r *PendingTransactionService) ListAutoPaging(ctx context.Context, query PendingTransactionListParams, opts ...option.RequestOption) *shared.PageAutoPager[PendingTransaction] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Pending Transactions are potential future additions and removals of money from
@@ -79,7 +79,7 @@ func (r *PhysicalCardService) List(ctx context.Context, query PhysicalCardListPa
 
 // List Physical Cards
 func (r *PhysicalCardService) ListAutoPaging(ctx context.Context, query PhysicalCardListParams, opts ...option.RequestOption) *shared.PageAutoPager[PhysicalCard] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Custom physical Visa cards that are shipped to your customers. The artwork is
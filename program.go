@@ -62,7 +62,7 @@ func (r *ProgramService) List(ctx context.Context, query ProgramListParams, opts
 
 // List Programs
 func (r *ProgramService) ListAutoPaging(ctx context.Context, query ProgramListParams, opts ...option.RequestOption) *shared.PageAutoPager[Program] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Programs determine the compliance and commercial terms of Accounts. By default,
@@ -71,7 +71,7 @@ func (r *RealTimePaymentsTransferService) List(ctx context.Context, query RealTi
 
 // List Real-Time Payments Transfers
 func (r *RealTimePaymentsTransferService) ListAutoPaging(ctx context.Context, query RealTimePaymentsTransferListParams, opts ...option.RequestOption) *shared.PageAutoPager[RealTimePaymentsTransfer] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Real-Time Payments transfers move funds, within seconds, between your Increase
@@ -0,0 +1,55 @@
+package increase
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/increase/increase-go/internal/requestconfig"
+)
+
+// ErrSandboxRequired matches every [*SandboxRequiredError] with errors.Is.
+var ErrSandboxRequired = errors.New("simulations require the sandbox environment")
+
+// SandboxRequiredError is returned, without sending the request, when a method
+// of [SimulationService] is called on a client that points at a host other than
+// the sandbox, such as production, unless the host was allowed with
+// [option.WithSimulationBaseURL].
+type SandboxRequiredError struct {
+	// The host the request would have been sent to.
+	Host string
+}
+
+func (e *SandboxRequiredError) Error() string {
+	return fmt.Sprintf("simulations require the sandbox environment (option.WithEnvironmentSandbox), but the client points at %s", e.Host)
+}
+
+func (e *SandboxRequiredError) Is(target error) bool {
+	return target == ErrSandboxRequired
+}
+
+// Retryable reports false, since the request would fail the same way again.
+func (e *SandboxRequiredError) Retryable() bool {
+	return false
+}
+
+// sandboxHost is the host of [option.WithEnvironmentSandbox].
+const sandboxHost = "sandbox.increase.com"
+
+// requireSandbox fails simulation requests to any host but the sandbox and the
+// hosts allowed with [option.WithSimulationBaseURL], once before they are sent.
+func requireSandbox(r *requestconfig.RequestConfig) error {
+	r.Preflight = append(r.Preflight, func(req *http.Request) error {
+		if strings.EqualFold(req.URL.Hostname(), sandboxHost) {
+			return nil
+		}
+		for _, host := range r.SimulationHosts {
+			if strings.EqualFold(req.URL.Host, host) {
+				return nil
+			}
+		}
+		return &SandboxRequiredError{Host: req.URL.Host}
+	})
+	return nil
+}
@@ -0,0 +1,73 @@
+package increase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/increase/increase-go"
+	"github.com/increase/increase-go/option"
+)
+
+func TestSimulationsRequireSandbox(t *testing.T) {
+	transport := &fakeTransport{}
+	attempts := 0
+	client := newFakeClient(transport, option.WithMiddleware(func(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+		attempts++
+		return next(req)
+	}))
+	params := increase.SimulationAccountStatementNewParams{AccountID: increase.F("account_in71c4amph0vgo2qllky")}
+
+	_, err := client.Simulations.AccountStatements.New(context.Background(), params)
+	var sandboxErr *increase.SandboxRequiredError
+	if !errors.As(err, &sandboxErr) || !errors.Is(err, increase.ErrSandboxRequired) || sandboxErr.Host != "api.increase.com" {
+		t.Fatalf("expected a *SandboxRequiredError for production, got %v", err)
+	}
+	if errors.Is(err, increase.ErrConnection) || increase.IsRetryable(err) {
+		t.Fatalf("expected a permanent error that is not a connection error, got %#v", err)
+	}
+	if len(transport.hosts) != 0 || attempts != 0 {
+		t.Fatalf("expected no request to be sent, got %v after %d attempts", transport.hosts, attempts)
+	}
+
+	sandbox := client.WithOptions(option.WithEnvironmentSandbox(), option.WithAPIKey("Sandbox API Key"))
+	if _, err := sandbox.Simulations.AccountStatements.New(context.Background(), params); err != nil {
+		t.Fatalf("expected the sandbox client to run simulations, got %v", err)
+	}
+	if _, err := client.Accounts.Get(context.Background(), "account_in71c4amph0vgo2qllky"); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"sandbox.increase.com Bearer Sandbox API Key", "api.increase.com Bearer My API Key"}
+	if strings.Join(transport.hosts, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected the derived client not to affect the original, got %v", transport.hosts)
+	}
+}
+
+func TestSimulationsRequireAllowedHost(t *testing.T) {
+	transport := &fakeTransport{}
+	proxy := newFakeClient(transport, option.WithBaseURL("https://increase-proxy.internal/"))
+	params := increase.SimulationAccountStatementNewParams{AccountID: increase.F("account_in71c4amph0vgo2qllky")}
+
+	_, err := proxy.Simulations.AccountStatements.New(context.Background(), params)
+	var sandboxErr *increase.SandboxRequiredError
+	if !errors.As(err, &sandboxErr) || sandboxErr.Host != "increase-proxy.internal" {
+		t.Fatalf("expected a *SandboxRequiredError for a proxy, got %v", err)
+	}
+	if len(transport.hosts) != 0 {
+		t.Fatalf("expected no request to be sent, got %v", transport.hosts)
+	}
+
+	mock := newFakeClient(transport, option.WithBaseURL("http://localhost:4010"), option.WithSimulationBaseURL("http://localhost:4010"))
+	if _, err := mock.Simulations.AccountStatements.New(context.Background(), params); err != nil {
+		t.Fatalf("expected simulations to be sent to an allowed host, got %v", err)
+	}
+	if _, err := proxy.Simulations.AccountStatements.New(context.Background(), params, option.WithSimulationBaseURL("https://increase-proxy.internal/")); err != nil {
+		t.Fatalf("expected a request option to allow the host, got %v", err)
+	}
+	expected := []string{"localhost:4010 Bearer My API Key", "increase-proxy.internal Bearer My API Key"}
+	if strings.Join(transport.hosts, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected the allowed hosts to be sent simulations, got %v", transport.hosts)
+	}
+}
@@ -23,6 +23,7 @@ func TestSimulationAccountStatementNew(t *testing.T) {
 	}
 	client := increase.NewClient(
 		option.WithBaseURL(baseURL),
+		option.WithSimulationBaseURL(baseURL),
 		option.WithAPIKey("My API Key"),
 	)
 	_, err := client.Simulations.AccountStatements.New(context.TODO(), increase.SimulationAccountStatementNewParams{
@@ -24,6 +24,7 @@ func TestSimulationAccountTransferComplete(t *testing.T) {
 	}
 	client := increase.NewClient(
 		option.WithBaseURL(baseURL),
+		option.WithSimulationBaseURL(baseURL),
 		option.WithAPIKey("My API Key"),
 	)
 	_, err := client.Simulations.AccountTransfers.Complete(context.TODO(), "account_transfer_7k9qe1ysdgqztnt63l7n")
@@ -24,6 +24,7 @@ func TestSimulationACHTransferNewInboundWithOptionalParams(t *testing.T) {
 	}
 	client := increase.NewClient(
 		option.WithBaseURL(baseURL),
+		option.WithSimulationBaseURL(baseURL),
 		option.WithAPIKey("My API Key"),
 	)
 	_, err := client.Simulations.ACHTransfers.NewInbound(context.TODO(), increase.SimulationACHTransferNewInboundParams{
@@ -56,6 +57,7 @@ func TestSimulationACHTransferReturnWithOptionalParams(t *testing.T) {
 	}
 	client := increase.NewClient(
 		option.WithBaseURL(baseURL),
+		option.WithSimulationBaseURL(baseURL),
 		option.WithAPIKey("My API Key"),
 	)
 	_, err := client.Simulations.ACHTransfers.Return(
@@ -85,6 +87,7 @@ func TestSimulationACHTransferSubmit(t *testing.T) {
 	}
 	client := increase.NewClient(
 		option.WithBaseURL(baseURL),
+		option.WithSimulationBaseURL(baseURL),
 		option.WithAPIKey("My API Key"),
 	)
 	_, err := client.Simulations.ACHTransfers.Submit(context.TODO(), "ach_transfer_uoxatyh3lt5evrsdvo7q")
@@ -23,6 +23,7 @@ func TestSimulationCardAuthorizeWithOptionalParams(t *testing.T) {
 	}
 	client := increase.NewClient(
 		option.WithBaseURL(baseURL),
+		option.WithSimulationBaseURL(baseURL),
 		option.WithAPIKey("My API Key"),
 	)
 	_, err := client.Simulations.Cards.Authorize(context.TODO(), increase.SimulationCardAuthorizeParams{
@@ -51,6 +52,7 @@ func TestSimulationCardSettlementWithOptionalParams(t *testing.T) {
 	}
 	client := increase.NewClient(
 		option.WithBaseURL(baseURL),
+		option.WithSimulationBaseURL(baseURL),
 		option.WithAPIKey("My API Key"),
 	)
 	_, err := client.Simulations.Cards.Settlement(context.TODO(), increase.SimulationCardSettlementParams{
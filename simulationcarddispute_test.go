@@ -23,6 +23,7 @@ func TestSimulationCardDisputeActionWithOptionalParams(t *testing.T) {
 	}
 	client := increase.NewClient(
 		option.WithBaseURL(baseURL),
+		option.WithSimulationBaseURL(baseURL),
 		option.WithAPIKey("My API Key"),
 	)
 	_, err := client.Simulations.CardDisputes.Action(
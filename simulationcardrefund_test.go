@@ -23,6 +23,7 @@ func TestSimulationCardRefundNew(t *testing.T) {
 	}
 	client := increase.NewClient(
 		option.WithBaseURL(baseURL),
+		option.WithSimulationBaseURL(baseURL),
 		option.WithAPIKey("My API Key"),
 	)
 	_, err := client.Simulations.CardRefunds.New(context.TODO(), increase.SimulationCardRefundNewParams{
@@ -23,6 +23,7 @@ func TestSimulationCheckTransferDeposit(t *testing.T) {
 	}
 	client := increase.NewClient(
 		option.WithBaseURL(baseURL),
+		option.WithSimulationBaseURL(baseURL),
 		option.WithAPIKey("My API Key"),
 	)
 	_, err := client.Simulations.CheckTransfers.Deposit(context.TODO(), "check_transfer_30b43acfu9vw8fyc4f5")
@@ -46,6 +47,7 @@ func TestSimulationCheckTransferMail(t *testing.T) {
 	}
 	client := increase.NewClient(
 		option.WithBaseURL(baseURL),
+		option.WithSimulationBaseURL(baseURL),
 		option.WithAPIKey("My API Key"),
 	)
 	_, err := client.Simulations.CheckTransfers.Mail(context.TODO(), "check_transfer_30b43acfu9vw8fyc4f5")
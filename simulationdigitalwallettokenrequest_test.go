@@ -23,6 +23,7 @@ func TestSimulationDigitalWalletTokenRequestNew(t *testing.T) {
 	}
 	client := increase.NewClient(
 		option.WithBaseURL(baseURL),
+		option.WithSimulationBaseURL(baseURL),
 		option.WithAPIKey("My API Key"),
 	)
 	_, err := client.Simulations.DigitalWalletTokenRequests.New(context.TODO(), increase.SimulationDigitalWalletTokenRequestNewParams{
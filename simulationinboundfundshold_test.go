@@ -23,6 +23,7 @@ func TestSimulationInboundFundsHoldRelease(t *testing.T) {
 	}
 	client := increase.NewClient(
 		option.WithBaseURL(baseURL),
+		option.WithSimulationBaseURL(baseURL),
 		option.WithAPIKey("My API Key"),
 	)
 	_, err := client.Simulations.InboundFundsHolds.Release(context.TODO(), "inbound_funds_hold_9vuasmywdo7xb3zt4071")
@@ -24,6 +24,7 @@ func TestSimulationInterestPaymentNewWithOptionalParams(t *testing.T) {
 	}
 	client := increase.NewClient(
 		option.WithBaseURL(baseURL),
+		option.WithSimulationBaseURL(baseURL),
 		option.WithAPIKey("My API Key"),
 	)
 	_, err := client.Simulations.InterestPayments.New(context.TODO(), increase.SimulationInterestPaymentNewParams{
@@ -23,6 +23,7 @@ func TestSimulationRealTimePaymentsTransferCompleteWithOptionalParams(t *testing
 	}
 	client := increase.NewClient(
 		option.WithBaseURL(baseURL),
+		option.WithSimulationBaseURL(baseURL),
 		option.WithAPIKey("My API Key"),
 	)
 	_, err := client.Simulations.RealTimePaymentsTransfers.Complete(
@@ -53,6 +54,7 @@ func TestSimulationRealTimePaymentsTransferNewInboundWithOptionalParams(t *testi
 	}
 	client := increase.NewClient(
 		option.WithBaseURL(baseURL),
+		option.WithSimulationBaseURL(baseURL),
 		option.WithAPIKey("My API Key"),
 	)
 	_, err := client.Simulations.RealTimePaymentsTransfers.NewInbound(context.TODO(), increase.SimulationRealTimePaymentsTransferNewInboundParams{
@@ -0,0 +1,279 @@
+package increase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/increase/increase-go/internal/shared"
+	"github.com/increase/increase-go/option"
+)
+
+// TailConfig configures [Tail].
+type TailConfig struct {
+	// How often the list is polled. Defaults to 5 seconds.
+	Interval time.Duration
+	// How long before the watermark each poll starts, so that items which show
+	// up in the list after newer ones are not missed. Defaults to 1 minute.
+	Overlap time.Duration
+	// Where the watermark is loaded from when tailing starts, and saved to once
+	// items were handed over. Without a store, nothing is saved.
+	Store WatermarkStore
+	// The creation time tailing starts from when there is no stored watermark.
+	// Items created before it are never handed over. Defaults to now.
+	Start time.Time
+}
+
+// Watermark is the position of [Tail] in a list.
+type Watermark struct {
+	// The creation time tailing started from, before which no item is handed
+	// over, even within the overlap.
+	Start time.Time `json:"start,omitempty"`
+	// The creation time of the newest item handed over.
+	CreatedAt time.Time `json:"created_at"`
+	// The creation times of the items handed over within the overlap before
+	// CreatedAt, by ID, so that they are not handed over again.
+	Seen map[string]time.Time `json:"seen,omitempty"`
+}
+
+// WatermarkStore persists the watermark of [Tail].
+type WatermarkStore interface {
+	// Load returns the stored watermark, or the zero Watermark if there is none.
+	Load(ctx context.Context) (Watermark, error)
+	Save(ctx context.Context, watermark Watermark) error
+}
+
+// FileWatermarkStore returns a store which keeps the watermark as JSON in the
+// file at path. The file is replaced atomically on each save.
+func FileWatermarkStore(path string) WatermarkStore {
+	return fileWatermarkStore{path: path}
+}
+
+type fileWatermarkStore struct {
+	path string
+}
+
+func (s fileWatermarkStore) Load(ctx context.Context) (Watermark, error) {
+	var watermark Watermark
+	raw, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return watermark, nil
+	}
+	if err != nil {
+		return watermark, err
+	}
+	if err := json.Unmarshal(raw, &watermark); err != nil {
+		return watermark, fmt.Errorf("invalid watermark in %s: %w", s.path, err)
+	}
+	return watermark, nil
+}
+
+func (s fileWatermarkStore) Save(ctx context.Context, watermark Watermark) error {
+	raw, err := json.Marshal(watermark)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(raw); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+// Tail polls a list for the items created since its watermark, and passes the
+// new ones to each, oldest first, until the context is canceled. It takes the
+// ListAutoPaging method of a service and its list params, whose CreatedAt
+// filter must be left unset. The items must have ID and CreatedAt fields:
+//
+//	err := increase.Tail(ctx, client.Events.ListAutoPaging, increase.EventListParams{}, increase.TailConfig{
+//		Store: increase.FileWatermarkStore("events.watermark"),
+//	}, func(event increase.Event) error {
+//		return handle(event)
+//	})
+//
+// Each poll lists the items created since the watermark, less the overlap but
+// not before the start, and skips the ones already handed over. The watermark
+// is saved after each poll that handed over items, so an item is handed over
+// again if the process stops before that: each should be idempotent. each is
+// never called concurrently; to receive the items on a channel, send them to it
+// from each.
+//
+// Tail returns the first error, from a request, the store, or returned by each,
+// after saving the watermark of the items handed over before it. Otherwise it
+// returns the error of the context once it is done.
+func Tail[P any, T any](ctx context.Context, list func(context.Context, P, ...option.RequestOption) *shared.PageAutoPager[T], params P, cfg TailConfig, each func(T) error) error {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 5 * time.Second
+	}
+	if cfg.Overlap <= 0 {
+		cfg.Overlap = time.Minute
+	}
+	if cfg.Start.IsZero() {
+		cfg.Start = time.Now()
+	}
+	if _, err := withCreatedAt(params, map[string]time.Time{"OnOrAfter": cfg.Start}); err != nil {
+		return err
+	}
+
+	watermark := Watermark{Start: cfg.Start, CreatedAt: cfg.Start}
+	if cfg.Store != nil {
+		stored, err := cfg.Store.Load(ctx)
+		if err != nil {
+			return err
+		}
+		if !stored.CreatedAt.IsZero() {
+			watermark = stored
+		}
+	}
+	if watermark.Seen == nil {
+		watermark.Seen = map[string]time.Time{}
+	}
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	for {
+		if err := tailOnce(ctx, list, params, cfg, &watermark, each); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+type tailItem[T any] struct {
+	value     T
+	id        string
+	createdAt time.Time
+}
+
+// tailOnce polls the list once, hands over the new items and saves the
+// watermark.
+func tailOnce[P any, T any](ctx context.Context, list func(context.Context, P, ...option.RequestOption) *shared.PageAutoPager[T], params P, cfg TailConfig, watermark *Watermark, each func(T) error) error {
+	onOrAfter := watermark.CreatedAt.Add(-cfg.Overlap)
+	if onOrAfter.Before(watermark.Start) {
+		onOrAfter = watermark.Start
+	}
+	params, err := withCreatedAt(params, map[string]time.Time{"OnOrAfter": onOrAfter})
+	if err != nil {
+		return err
+	}
+	pager := list(ctx, params)
+	defer pager.Close()
+	var items []tailItem[T]
+	for pager.Next() {
+		item := tailItem[T]{value: pager.Current()}
+		item.id, item.createdAt, err = identify(item.value)
+		if err != nil {
+			return err
+		}
+		if _, ok := watermark.Seen[item.id]; !ok && !item.createdAt.Before(watermark.Start) {
+			items = append(items, item)
+		}
+	}
+	if err := pager.Err(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+
+	// Lists are newest first: reverse them, so that items created at the same
+	// time stay in the order they were created in.
+	for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+		items[i], items[j] = items[j], items[i]
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].createdAt.Before(items[j].createdAt) })
+
+	var handed int
+	for _, item := range items {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		if err = each(item.value); err != nil {
+			break
+		}
+		handed++
+		watermark.Seen[item.id] = item.createdAt
+		if item.createdAt.After(watermark.CreatedAt) {
+			watermark.CreatedAt = item.createdAt
+		}
+	}
+	if handed == 0 {
+		return err
+	}
+	for id, createdAt := range watermark.Seen {
+		if createdAt.Before(watermark.CreatedAt.Add(-cfg.Overlap)) {
+			delete(watermark.Seen, id)
+		}
+	}
+	if cfg.Store != nil {
+		if saveErr := cfg.Store.Save(ctx, *watermark); saveErr != nil {
+			return errors.Join(err, saveErr)
+		}
+	}
+	return err
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// identify returns the ID and CreatedAt fields of an item.
+func identify(item any) (string, time.Time, error) {
+	v := reflect.ValueOf(item)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return "", time.Time{}, fmt.Errorf("%T has no ID and CreatedAt fields", item)
+	}
+	id, createdAt := v.FieldByName("ID"), v.FieldByName("CreatedAt")
+	if !id.IsValid() || id.Kind() != reflect.String || !createdAt.IsValid() || createdAt.Type() != timeType {
+		return "", time.Time{}, fmt.Errorf("%T has no ID and CreatedAt fields", item)
+	}
+	return id.String(), createdAt.Interface().(time.Time), nil
+}
+
+// Tail polls the list of events for the ones created since the watermark, and
+// passes them to each, oldest first. See [Tail].
+func (r *EventService) Tail(ctx context.Context, query EventListParams, cfg TailConfig, each func(Event) error, opts ...option.RequestOption) error {
+	return Tail(ctx, func(ctx context.Context, query EventListParams, more ...option.RequestOption) *shared.PageAutoPager[Event] {
+		return r.ListAutoPaging(ctx, query, append(opts[:len(opts):len(opts)], more...)...)
+	}, query, cfg, each)
+}
+
+// Tail polls the list of transactions for the ones created since the
+// watermark, and passes them to each, oldest first. See [Tail].
+func (r *TransactionService) Tail(ctx context.Context, query TransactionListParams, cfg TailConfig, each func(Transaction) error, opts ...option.RequestOption) error {
+	return Tail(ctx, func(ctx context.Context, query TransactionListParams, more ...option.RequestOption) *shared.PageAutoPager[Transaction] {
+		return r.ListAutoPaging(ctx, query, append(opts[:len(opts):len(opts)], more...)...)
+	}, query, cfg, each)
+}
+
+// Tail polls the list of pending transactions for the ones created since the
+// watermark, and passes them to each, oldest first. See [Tail].
+func (r *PendingTransactionService) Tail(ctx context.Context, query PendingTransactionListParams, cfg TailConfig, each func(PendingTransaction) error, opts ...option.RequestOption) error {
+	return Tail(ctx, func(ctx context.Context, query PendingTransactionListParams, more ...option.RequestOption) *shared.PageAutoPager[PendingTransaction] {
+		return r.ListAutoPaging(ctx, query, append(opts[:len(opts):len(opts)], more...)...)
+	}, query, cfg, each)
+}
@@ -0,0 +1,146 @@
+package increase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/increase/increase-go"
+)
+
+// newEventClient returns a client listing n events, one created every second
+// from partitionStart, in pages of 10. More can be added while they are tailed.
+func newEventClient(n int) (*increase.Client, *fakeTransport) {
+	transport := &fakeTransport{pageSize: 10}
+	for i := 0; i < n; i++ {
+		transport.add(fmt.Sprintf("event_%d", i), partitionStart.Add(time.Duration(i)*time.Second))
+	}
+	return newFakeClient(transport), transport
+}
+
+func TestTail(t *testing.T) {
+	client, transport := newEventClient(15)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var ids []string
+	err := client.Events.Tail(ctx, increase.EventListParams{}, increase.TailConfig{
+		Interval: 10 * time.Millisecond,
+		Start:    partitionStart,
+	}, func(event increase.Event) error {
+		ids = append(ids, event.ID)
+		switch len(ids) {
+		case 15:
+			// A late event, created before the newest one handed over, and a new
+			// one.
+			transport.add("event_late", partitionStart.Add(10*time.Second+time.Millisecond))
+			transport.add("event_15", partitionStart.Add(15*time.Second))
+		case 17:
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the context to be canceled, got %v", err)
+	}
+	var expected []string
+	for i := 0; i < 15; i++ {
+		expected = append(expected, fmt.Sprintf("event_%d", i))
+	}
+	expected = append(expected, "event_late", "event_15")
+	if strings.Join(ids, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected %v, got %v", expected, ids)
+	}
+}
+
+func TestTailSkipsItemsBeforeStart(t *testing.T) {
+	client, transport := newEventClient(0)
+	start := partitionStart.Add(time.Minute)
+	transport.add("event_before", start.Add(-30*time.Second))
+	transport.add("event_after", start.Add(time.Second))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var ids []string
+	err := client.Events.Tail(ctx, increase.EventListParams{}, increase.TailConfig{
+		Interval: 10 * time.Millisecond,
+		Start:    start,
+	}, func(event increase.Event) error {
+		ids = append(ids, event.ID)
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the context to be canceled, got %v", err)
+	}
+	if strings.Join(ids, ",") != "event_after" {
+		t.Fatalf("expected only the event created after the start, got %v", ids)
+	}
+}
+
+func TestTailResumesFromStore(t *testing.T) {
+	client, _ := newEventClient(15)
+	store := increase.FileWatermarkStore(filepath.Join(t.TempDir(), "events.watermark"))
+	failure := errors.New("failure")
+	var ids []string
+	err := client.Events.Tail(context.Background(), increase.EventListParams{}, increase.TailConfig{
+		Interval: 10 * time.Millisecond,
+		Start:    partitionStart,
+		Store:    store,
+	}, func(event increase.Event) error {
+		if event.ID == "event_8" {
+			return failure
+		}
+		ids = append(ids, event.ID)
+		return nil
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected the error of each, got %v", err)
+	}
+	if len(ids) != 8 {
+		t.Fatalf("expected 8 events before the failure, got %v", ids)
+	}
+
+	watermark, err := store.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !watermark.CreatedAt.Equal(partitionStart.Add(7*time.Second)) || len(watermark.Seen) != 8 {
+		t.Fatalf("unexpected watermark %+v", watermark)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ids = nil
+	err = client.Events.Tail(ctx, increase.EventListParams{}, increase.TailConfig{
+		Interval: 10 * time.Millisecond,
+		Store:    store,
+	}, func(event increase.Event) error {
+		ids = append(ids, event.ID)
+		if len(ids) == 7 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the context to be canceled, got %v", err)
+	}
+	if ids[0] != "event_8" || ids[6] != "event_14" {
+		t.Fatalf("expected to resume from event_8, got %v", ids)
+	}
+}
+
+func TestTailRejectsCreatedAt(t *testing.T) {
+	client, _ := newEventClient(1)
+	params := increase.EventListParams{
+		CreatedAt: increase.F(increase.EventListParamsCreatedAt{After: increase.F(partitionStart)}),
+	}
+	err := client.Events.Tail(context.Background(), params, increase.TailConfig{}, func(event increase.Event) error {
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -63,7 +63,7 @@ func (r *TransactionService) List(ctx context.Context, query TransactionListPara
 
 // List Transactions
 func (r *TransactionService) ListAutoPaging(ctx context.Context, query TransactionListParams, opts ...option.RequestOption) *shared.PageAutoPager[Transaction] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Transactions are the immutable additions and removals of money from your bank
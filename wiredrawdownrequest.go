@@ -70,7 +70,7 @@ func (r *WireDrawdownRequestService) List(ctx context.Context, query WireDrawdow
 
 // List Wire Drawdown Requests
 func (r *WireDrawdownRequestService) ListAutoPaging(ctx context.Context, query WireDrawdownRequestListParams, opts ...option.RequestOption) *shared.PageAutoPager[WireDrawdownRequest] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Wire drawdown requests enable you to request that someone else send you a wire.
@@ -71,7 +71,7 @@ func (r *WireTransferService) List(ctx context.Context, query WireTransferListPa
 
 // List Wire Transfers
 func (r *WireTransferService) ListAutoPaging(ctx context.Context, query WireTransferListParams, opts ...option.RequestOption) *shared.PageAutoPager[WireTransfer] {
-	return shared.NewPageAutoPager(r.List(ctx, query, opts...))
+	return shared.ListPageAutoPager(ctx, r.List, query, opts...)
 }
 
 // Approve a Wire Transfer